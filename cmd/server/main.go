@@ -0,0 +1,411 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	proto "example-service/example-service/proto"
+	"example-service/internal/adapters/inbound/gateway"
+	grpcHandler "example-service/internal/adapters/inbound/grpc"
+	httpHandler "example-service/internal/adapters/inbound/http"
+	inboundKafka "example-service/internal/adapters/inbound/kafka"
+	inboundRedis "example-service/internal/adapters/inbound/redis"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/adapters/outbound/eventbus"
+	"example-service/internal/adapters/outbound/kafka"
+	redisAdapter "example-service/internal/adapters/outbound/redis"
+	"example-service/internal/adapters/outbound/webhook"
+	"example-service/internal/application"
+	"example-service/internal/config"
+	"example-service/internal/ports/external"
+	"example-service/internal/ports/repositories"
+	"example-service/internal/ports/services"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatalf("migrate error: %v", err)
+		}
+		return
+	}
+
+	if err := run(); err != nil {
+		log.Fatalf("server error: %v", err)
+	}
+}
+
+// run wires all dependencies, starts the servers and blocks until shutdown
+func run() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := openStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.close()
+
+	// Initialize adapters and services
+	encoder, err := envelope.NewEncoder(envelope.Config{
+		Source:     cfg.EventSource,
+		SchemaBase: cfg.EventSchemaBase,
+		Format:     cfg.EventFormat,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Redis is only needed when it is the event broker
+	var redisClient *goredis.Client
+	if cfg.EventBroker == config.EventBrokerRedis {
+		redisClient, err = redisAdapter.NewClient(context.Background(), cfg.RedisURL, cfg.RedisPassword)
+		if err != nil {
+			return err
+		}
+		defer redisClient.Close()
+	}
+
+	eventPublisher, err := newEventPublisher(cfg, encoder, redisClient)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := eventPublisher.Close(); err != nil {
+			log.Printf("failed to close event publisher: %v", err)
+		}
+	}()
+
+	// In-process side effects subscribe to the event bus, which receives
+	// relayed events after the broker accepted them. Subscribers are
+	// registered with eventBus.Subscribe; their failures are logged and
+	// counted in the publish stats but never fail the relay, since the
+	// broker already has the event.
+	eventBus := eventbus.NewEventBus()
+	defer eventBus.Close()
+
+	// Events are stored in the outbox and queued for webhook subscribers with
+	// each change, then relayed and delivered in the background. The publish
+	// policy decides how broker failures are handled.
+	brokerPublisher := application.NewMultiPublisher(eventPublisher, eventBus.BestEffort())
+	publishMetrics := application.NewPublishMetrics()
+	outboxPublisher, deadLetterAfter, err := newOutboxPublisher(cfg, store.outbox, brokerPublisher, publishMetrics)
+	if err != nil {
+		return err
+	}
+	exampleService := application.NewExampleService(
+		store.examples,
+		application.NewMultiPublisher(
+			outboxPublisher,
+			application.NewWebhookPublisher(store.subscriptions, store.deliveries),
+		),
+		store.transactor,
+	)
+	webhookService := application.NewWebhookService(store.subscriptions, store.deliveries)
+	eventAdminService := application.NewEventAdminService(store.outbox, publishMetrics, eventBus)
+
+	relay := application.NewOutboxRelay(store.outbox, brokerPublisher, application.OutboxRelayConfig{
+		PollInterval:    cfg.OutboxPollInterval,
+		BatchSize:       cfg.OutboxBatchSize,
+		DeadLetterAfter: deadLetterAfter,
+		Metrics:         publishMetrics,
+	})
+	defer runInBackground(relay.Run)()
+
+	// Webhook bodies are always JSON, whatever the broker event format
+	webhookEncoder, err := envelope.NewEncoder(envelope.Config{
+		Source:     cfg.EventSource,
+		SchemaBase: cfg.EventSchemaBase,
+		Format:     envelope.FormatJSON,
+	})
+	if err != nil {
+		return err
+	}
+	webhookDispatcher := application.NewWebhookDispatcher(
+		store.subscriptions,
+		store.deliveries,
+		webhook.NewSender(webhookEncoder, cfg.WebhookTimeout),
+		application.WebhookDispatcherConfig{
+			PollInterval: cfg.WebhookPollInterval,
+			// Deliveries are sent in parallel, so a batch takes at most
+			// about one timeout
+			Lease:        cfg.WebhookTimeout + time.Minute,
+			MaxAttempts:  cfg.WebhookMaxAttempts,
+			DisableAfter: cfg.WebhookDisableAfter,
+		},
+	)
+	defer runInBackground(webhookDispatcher.Run)()
+
+	// WatchExamples and the HTTP change stream follow the outbox through the
+	// change feed
+	changeFeed := application.NewChangeFeed(store.outbox, application.ChangeFeedConfig{
+		PollInterval: cfg.ChangeFeedPollInterval,
+	})
+	stopChangeFeed := runInBackground(changeFeed.Run)
+	defer stopChangeFeed()
+
+	// Events from other services are consumed when topics or streams are
+	// configured
+	dispatcher := application.NewEventDispatcher(
+		consumerGroup(cfg),
+		store.processed,
+		store.transactor,
+	)
+	application.RegisterEventHandlers(dispatcher, exampleService)
+	consumer, err := newEventConsumer(cfg, dispatcher, redisClient)
+	if err != nil {
+		return err
+	}
+	if consumer != nil {
+		defer startEventConsumer(consumer)()
+	}
+
+	// gRPC server
+	grpcServer := grpc.NewServer()
+	proto.RegisterExampleServiceServer(grpcServer, grpcHandler.NewHandler(exampleService, changeFeed))
+
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return err
+	}
+
+	// HTTP server
+	restHandler, closeREST, err := newRESTHandler(cfg, exampleService, changeFeed, webhookService, eventAdminService)
+	if err != nil {
+		return err
+	}
+	defer closeREST()
+
+	httpServer := &http.Server{
+		Addr:              ":" + cfg.HTTPPort,
+		Handler:           restHandler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 2)
+
+	go func() {
+		log.Printf("gRPC server listening on :%s", cfg.GRPCPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			errCh <- err
+		}
+	}()
+
+	go func() {
+		log.Printf("HTTP server listening on :%s", cfg.HTTPPort)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	// Wait for a termination signal or a server failure
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		log.Println("Shutdown signal received, draining servers")
+	case serveErr = <-errCh:
+		log.Printf("Server failed, shutting down: %v", serveErr)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Stopping the change feed ends open watch streams, which would
+	// otherwise hold up the graceful shutdown
+	stopChangeFeed()
+	shutdownServers(shutdownCtx, httpServer, grpcServer)
+
+	log.Println("Server stopped")
+	return serveErr
+}
+
+// eventConsumer reads events from the broker until its context is cancelled
+type eventConsumer interface {
+	Run(ctx context.Context)
+	Close()
+}
+
+// newEventPublisher creates the publisher for the configured event broker
+func newEventPublisher(cfg *config.Config, encoder *envelope.Encoder, redisClient *goredis.Client) (external.EventPublisher, error) {
+	switch cfg.EventBroker {
+	case config.EventBrokerKafka:
+		return kafka.NewEventPublisher(cfg.Kafka, encoder)
+	case config.EventBrokerRedis:
+		return redisAdapter.NewEventPublisher(redisClient, cfg.RedisStreams, encoder), nil
+	case config.EventBrokerNone:
+		// Relayed events still reach the event bus
+		return application.NewMultiPublisher(), nil
+	default:
+		return nil, fmt.Errorf("unknown event broker %q", cfg.EventBroker)
+	}
+}
+
+// newOutboxPublisher creates the publisher ExampleService stores events with
+// and the number of attempts after which the relay parks an event, zero for
+// never, according to the publish policy
+func newOutboxPublisher(
+	cfg *config.Config,
+	outbox repositories.OutboxRepository,
+	broker external.EventPublisher,
+	metrics *application.PublishMetrics,
+) (external.EventPublisher, int, error) {
+	switch cfg.PublishPolicy {
+	case config.PublishPolicyFail:
+		// Rolling back the change is what makes a failed publish safe, and
+		// in-memory storage has no transactions
+		if cfg.DatabaseURL == config.MemoryDatabaseURL {
+			return nil, 0, errors.New("EVENT_PUBLISH_POLICY=fail needs transactions, which memory:// storage does not have")
+		}
+		return application.NewSyncOutboxPublisher(outbox, broker, metrics), 0, nil
+	case config.PublishPolicyRetry:
+		return application.NewOutboxPublisher(outbox), 0, nil
+	case config.PublishPolicyDeadLetter:
+		if cfg.OutboxMaxAttempts <= 0 {
+			return nil, 0, fmt.Errorf("OUTBOX_MAX_ATTEMPTS must be positive, got %d", cfg.OutboxMaxAttempts)
+		}
+		return application.NewOutboxPublisher(outbox), cfg.OutboxMaxAttempts, nil
+	default:
+		return nil, 0, fmt.Errorf("unknown event publish policy %q", cfg.PublishPolicy)
+	}
+}
+
+// newEventConsumer creates the consumer for the configured event broker, or
+// returns nil when nothing is to be consumed. It refuses to consume without
+// any registered handler, which would acknowledge every event unhandled.
+func newEventConsumer(cfg *config.Config, dispatcher *application.EventDispatcher, redisClient *goredis.Client) (eventConsumer, error) {
+	switch {
+	case cfg.EventBroker == config.EventBrokerKafka && len(cfg.Kafka.Consumer.Topics) > 0:
+		if len(dispatcher.EventTypes()) == 0 {
+			return nil, errors.New("KAFKA_CONSUMER_TOPICS is set but no event handlers are registered")
+		}
+		log.Printf("Consuming %v events from Kafka topics %v", dispatcher.EventTypes(), cfg.Kafka.Consumer.Topics)
+		return inboundKafka.NewConsumer(cfg.Kafka, dispatcher)
+	case cfg.EventBroker == config.EventBrokerRedis && len(cfg.RedisStreams.ConsumerStreams) > 0:
+		if len(dispatcher.EventTypes()) == 0 {
+			return nil, errors.New("REDIS_CONSUMER_STREAMS is set but no event handlers are registered")
+		}
+		log.Printf("Consuming %v events from Redis streams %v", dispatcher.EventTypes(), cfg.RedisStreams.ConsumerStreams)
+		return inboundRedis.NewConsumer(redisClient, cfg.RedisStreams, dispatcher)
+	default:
+		return nil, nil
+	}
+}
+
+// consumerGroup returns the consumer group of the configured event broker,
+// which also names the consumer in the processed events table
+func consumerGroup(cfg *config.Config) string {
+	if cfg.EventBroker == config.EventBrokerRedis {
+		return cfg.RedisStreams.ConsumerGroup
+	}
+	return cfg.Kafka.Consumer.GroupID
+}
+
+// startEventConsumer runs the consumer in the background. The returned func
+// stops it and waits for in-flight events to finish.
+func startEventConsumer(consumer eventConsumer) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		consumer.Run(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		consumer.Close()
+	}
+}
+
+// runInBackground runs fn in a goroutine until the returned func is called.
+// The returned func cancels fn's context and waits for it to return; calls
+// after the first do nothing.
+func runInBackground(fn func(ctx context.Context)) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// newRESTHandler builds the HTTP handler for the configured HTTP mode. The
+// webhook API, the event admin API and the change stream are served by the
+// mux handler in both modes. The returned func releases any resources held
+// by the handler.
+func newRESTHandler(
+	cfg *config.Config,
+	exampleService services.ExampleService,
+	exampleWatcher services.ExampleWatcher,
+	webhookService services.WebhookService,
+	eventAdminService services.EventAdminService,
+) (http.Handler, func(), error) {
+	router := mux.NewRouter()
+	router.Use(httpHandler.RequestID)
+	httpHandler.NewWebhookHandler(webhookService).RegisterRoutes(router)
+	httpHandler.NewAdminHandler(eventAdminService).RegisterRoutes(router)
+	httpHandler.NewStreamHandler(exampleWatcher, cfg.StreamHeartbeatInterval).RegisterRoutes(router)
+
+	switch cfg.HTTPMode {
+	case config.HTTPModeGateway:
+		gw, err := gateway.NewHandler(context.Background(), "localhost:"+cfg.GRPCPort)
+		if err != nil {
+			return nil, nil, err
+		}
+		router.PathPrefix("/").Handler(gw)
+		log.Println("Serving REST API through grpc-gateway")
+		return router, func() { _ = gw.Close() }, nil
+	case config.HTTPModeMux:
+		httpHandler.NewHandler(exampleService).RegisterRoutes(router)
+		log.Println("Serving REST API through gorilla/mux handler")
+		return router, func() {}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown HTTP mode %q", cfg.HTTPMode)
+	}
+}
+
+// shutdownServers drains in-flight requests on both servers, forcing them
+// closed once ctx expires
+func shutdownServers(ctx context.Context, httpServer *http.Server, grpcServer *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(done)
+	}()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Printf("HTTP server shutdown: %v", err)
+		_ = httpServer.Close()
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("gRPC graceful stop timed out, forcing stop")
+		grpcServer.Stop()
+		<-done
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"example-service/internal/config"
+	"example-service/internal/database"
+
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: example-service migrate up|down|status|to <version>"
+
+// runMigrate runs the migrate subcommand
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.DatabaseURL == config.MemoryDatabaseURL {
+		return errors.New("in-memory storage has no schema to migrate")
+	}
+	db, err := database.InitGORM(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := database.Close(db); err != nil {
+			log.Printf("failed to close database: %v", err)
+		}
+	}()
+
+	migrator, err := database.NewMigrator(db, migrateOptions(cfg))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		return migrator.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		return migrator.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		return migrator.To(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		return printMigrationStatus(ctx, migrator, db, migrateOptions(cfg))
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// printMigrationStatus prints every migration and whether the schema
+// matches the models, failing when it does not
+func printMigrationStatus(ctx context.Context, migrator *database.Migrator, db *gorm.DB, opts database.MigrateOptions) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if status.Up == "" {
+			applied += " (unknown to this build)"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", status.Migration, applied)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if err := database.CheckSchema(db, opts); err != nil {
+		return err
+	}
+	fmt.Println("Schema matches the models")
+	return nil
+}
+
+// migrateOptions returns the migration options for the configuration
+func migrateOptions(cfg *config.Config) database.MigrateOptions {
+	return database.MigrateOptions{
+		CaseInsensitiveNames: cfg.CaseInsensitiveNames,
+	}
+}
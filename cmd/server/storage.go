@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"example-service/internal/adapters/outbound/memory"
+	"example-service/internal/adapters/outbound/postgres"
+	"example-service/internal/config"
+	"example-service/internal/database"
+	"example-service/internal/ports/repositories"
+)
+
+// storage holds the repositories of the configured database
+type storage struct {
+	examples      repositories.ExampleRepository
+	outbox        repositories.OutboxRepository
+	processed     repositories.ProcessedEventRepository
+	subscriptions repositories.WebhookSubscriptionRepository
+	deliveries    repositories.WebhookDeliveryRepository
+	// transactor is nil for in-memory storage, which has no transactions:
+	// each write is applied on its own and a failed request may leave the
+	// writes made before the failure in place
+	transactor repositories.Transactor
+	close      func()
+}
+
+// openStorage creates in-memory storage for DATABASE_URL=memory://, and
+// otherwise connects to PostgreSQL, migrates it if configured and checks
+// the schema
+func openStorage(cfg *config.Config) (*storage, error) {
+	if cfg.DatabaseURL == config.MemoryDatabaseURL {
+		log.Println("Using in-memory storage, data is lost when the service stops")
+		store := memory.NewStore(memory.Options{CaseInsensitiveNames: cfg.CaseInsensitiveNames})
+		return &storage{
+			examples:      memory.NewExampleRepository(store),
+			outbox:        memory.NewOutboxRepository(store),
+			processed:     memory.NewProcessedEventRepository(store),
+			subscriptions: memory.NewWebhookSubscriptionRepository(store),
+			deliveries:    memory.NewWebhookDeliveryRepository(store),
+			close:         func() {},
+		}, nil
+	}
+
+	db, err := database.InitGORM(cfg.DatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+	closeDB := func() {
+		if err := database.Close(db); err != nil {
+			log.Printf("failed to close database: %v", err)
+		}
+	}
+
+	// Replicas starting together take turns migrating; with MIGRATE_ON_START
+	// off, migrations are run with the migrate command instead
+	if cfg.MigrateOnStart {
+		migrator, err := database.NewMigrator(db, migrateOptions(cfg))
+		if err != nil {
+			closeDB()
+			return nil, err
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			closeDB()
+			return nil, err
+		}
+	}
+	if err := database.CheckSchema(db, migrateOptions(cfg)); err != nil {
+		closeDB()
+		return nil, err
+	}
+
+	return &storage{
+		examples:      postgres.NewExampleRepository(db),
+		outbox:        postgres.NewOutboxRepository(db),
+		processed:     postgres.NewProcessedEventRepository(db),
+		subscriptions: postgres.NewWebhookSubscriptionRepository(db),
+		deliveries:    postgres.NewWebhookDeliveryRepository(db),
+		transactor:    postgres.NewTransactor(db),
+		close:         closeDB,
+	}, nil
+}
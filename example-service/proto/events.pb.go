@@ -0,0 +1,505 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: proto/events.proto
+
+// Payloads of the domain events published by the example service. A changed
+// payload is a new message registered as the next version of its event type,
+// and every version must stay readable by consumers of the one before it:
+// fields may be added, and removed fields must have their number and name
+// reserved. The registry checks this at startup. A change that cannot be
+// made compatibly needs a new event type.
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExampleCreated is version 1 of the event published when an example is
+// created
+type ExampleCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExampleId     int64                  `protobuf:"varint,1,opt,name=example_id,json=exampleId,proto3" json:"example_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExampleCreated) Reset() {
+	*x = ExampleCreated{}
+	mi := &file_proto_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExampleCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleCreated) ProtoMessage() {}
+
+func (x *ExampleCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleCreated.ProtoReflect.Descriptor instead.
+func (*ExampleCreated) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleCreated) GetExampleId() int64 {
+	if x != nil {
+		return x.ExampleId
+	}
+	return 0
+}
+
+func (x *ExampleCreated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExampleCreated) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// ExampleUpdated is version 1 of the event published when an example is
+// updated
+type ExampleUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExampleId     int64                  `protobuf:"varint,1,opt,name=example_id,json=exampleId,proto3" json:"example_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExampleUpdated) Reset() {
+	*x = ExampleUpdated{}
+	mi := &file_proto_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExampleUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleUpdated) ProtoMessage() {}
+
+func (x *ExampleUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleUpdated.ProtoReflect.Descriptor instead.
+func (*ExampleUpdated) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExampleUpdated) GetExampleId() int64 {
+	if x != nil {
+		return x.ExampleId
+	}
+	return 0
+}
+
+func (x *ExampleUpdated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExampleUpdated) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// ExampleDeleted is version 1 of the event published when an example is
+// deleted
+type ExampleDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExampleId     int64                  `protobuf:"varint,1,opt,name=example_id,json=exampleId,proto3" json:"example_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExampleDeleted) Reset() {
+	*x = ExampleDeleted{}
+	mi := &file_proto_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExampleDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleDeleted) ProtoMessage() {}
+
+func (x *ExampleDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleDeleted.ProtoReflect.Descriptor instead.
+func (*ExampleDeleted) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExampleDeleted) GetExampleId() int64 {
+	if x != nil {
+		return x.ExampleId
+	}
+	return 0
+}
+
+func (x *ExampleDeleted) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// ExampleCreatedV2 is version 2 of ExampleCreated, adding the status
+type ExampleCreatedV2 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExampleId     int64                  `protobuf:"varint,1,opt,name=example_id,json=exampleId,proto3" json:"example_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExampleCreatedV2) Reset() {
+	*x = ExampleCreatedV2{}
+	mi := &file_proto_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExampleCreatedV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleCreatedV2) ProtoMessage() {}
+
+func (x *ExampleCreatedV2) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleCreatedV2.ProtoReflect.Descriptor instead.
+func (*ExampleCreatedV2) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExampleCreatedV2) GetExampleId() int64 {
+	if x != nil {
+		return x.ExampleId
+	}
+	return 0
+}
+
+func (x *ExampleCreatedV2) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExampleCreatedV2) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ExampleCreatedV2) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ExampleUpdatedV2 is version 2 of ExampleUpdated, adding the status
+type ExampleUpdatedV2 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExampleId     int64                  `protobuf:"varint,1,opt,name=example_id,json=exampleId,proto3" json:"example_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExampleUpdatedV2) Reset() {
+	*x = ExampleUpdatedV2{}
+	mi := &file_proto_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExampleUpdatedV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleUpdatedV2) ProtoMessage() {}
+
+func (x *ExampleUpdatedV2) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleUpdatedV2.ProtoReflect.Descriptor instead.
+func (*ExampleUpdatedV2) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExampleUpdatedV2) GetExampleId() int64 {
+	if x != nil {
+		return x.ExampleId
+	}
+	return 0
+}
+
+func (x *ExampleUpdatedV2) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExampleUpdatedV2) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ExampleUpdatedV2) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ExampleDeletedV2 is version 2 of ExampleDeleted, adding the status the
+// example had when it was deleted
+type ExampleDeletedV2 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExampleId     int64                  `protobuf:"varint,1,opt,name=example_id,json=exampleId,proto3" json:"example_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExampleDeletedV2) Reset() {
+	*x = ExampleDeletedV2{}
+	mi := &file_proto_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExampleDeletedV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleDeletedV2) ProtoMessage() {}
+
+func (x *ExampleDeletedV2) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleDeletedV2.ProtoReflect.Descriptor instead.
+func (*ExampleDeletedV2) Descriptor() ([]byte, []int) {
+	return file_proto_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExampleDeletedV2) GetExampleId() int64 {
+	if x != nil {
+		return x.ExampleId
+	}
+	return 0
+}
+
+func (x *ExampleDeletedV2) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ExampleDeletedV2) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_proto_events_proto protoreflect.FileDescriptor
+
+const file_proto_events_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/events.proto\x12\x11example.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"}\n" +
+	"\x0eExampleCreated\x12\x1d\n" +
+	"\n" +
+	"example_id\x18\x01 \x01(\x03R\texampleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"}\n" +
+	"\x0eExampleUpdated\x12\x1d\n" +
+	"\n" +
+	"example_id\x18\x01 \x01(\x03R\texampleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"i\n" +
+	"\x0eExampleDeleted\x12\x1d\n" +
+	"\n" +
+	"example_id\x18\x01 \x01(\x03R\texampleId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x97\x01\n" +
+	"\x10ExampleCreatedV2\x12\x1d\n" +
+	"\n" +
+	"example_id\x18\x01 \x01(\x03R\texampleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\x97\x01\n" +
+	"\x10ExampleUpdatedV2\x12\x1d\n" +
+	"\n" +
+	"example_id\x18\x01 \x01(\x03R\texampleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\x83\x01\n" +
+	"\x10ExampleDeletedV2\x12\x1d\n" +
+	"\n" +
+	"example_id\x18\x01 \x01(\x03R\texampleId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06statusB\x17Z\x15example-service/protob\x06proto3"
+
+var (
+	file_proto_events_proto_rawDescOnce sync.Once
+	file_proto_events_proto_rawDescData []byte
+)
+
+func file_proto_events_proto_rawDescGZIP() []byte {
+	file_proto_events_proto_rawDescOnce.Do(func() {
+		file_proto_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)))
+	})
+	return file_proto_events_proto_rawDescData
+}
+
+var file_proto_events_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_events_proto_goTypes = []any{
+	(*ExampleCreated)(nil),        // 0: example.events.v1.ExampleCreated
+	(*ExampleUpdated)(nil),        // 1: example.events.v1.ExampleUpdated
+	(*ExampleDeleted)(nil),        // 2: example.events.v1.ExampleDeleted
+	(*ExampleCreatedV2)(nil),      // 3: example.events.v1.ExampleCreatedV2
+	(*ExampleUpdatedV2)(nil),      // 4: example.events.v1.ExampleUpdatedV2
+	(*ExampleDeletedV2)(nil),      // 5: example.events.v1.ExampleDeletedV2
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_proto_events_proto_depIdxs = []int32{
+	6, // 0: example.events.v1.ExampleCreated.timestamp:type_name -> google.protobuf.Timestamp
+	6, // 1: example.events.v1.ExampleUpdated.timestamp:type_name -> google.protobuf.Timestamp
+	6, // 2: example.events.v1.ExampleDeleted.timestamp:type_name -> google.protobuf.Timestamp
+	6, // 3: example.events.v1.ExampleCreatedV2.timestamp:type_name -> google.protobuf.Timestamp
+	6, // 4: example.events.v1.ExampleUpdatedV2.timestamp:type_name -> google.protobuf.Timestamp
+	6, // 5: example.events.v1.ExampleDeletedV2.timestamp:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_events_proto_init() }
+func file_proto_events_proto_init() {
+	if File_proto_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_events_proto_rawDesc), len(file_proto_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_events_proto_goTypes,
+		DependencyIndexes: file_proto_events_proto_depIdxs,
+		MessageInfos:      file_proto_events_proto_msgTypes,
+	}.Build()
+	File_proto_events_proto = out.File
+	file_proto_events_proto_goTypes = nil
+	file_proto_events_proto_depIdxs = nil
+}
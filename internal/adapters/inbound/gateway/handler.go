@@ -0,0 +1,162 @@
+package gateway
+
+import (
+	"context"
+	proto "example-service/example-service/proto"
+	apperrors "example-service/pkg/errors"
+	"example-service/pkg/etag"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protov2 "google.golang.org/protobuf/proto"
+)
+
+// Handler serves the REST API by proxying requests to the gRPC server
+// through grpc-gateway, so both transports share one implementation
+type Handler struct {
+	mux  *runtime.ServeMux
+	conn *grpc.ClientConn
+}
+
+// NewHandler creates a gateway handler that forwards to the gRPC server at endpoint
+func NewHandler(ctx context.Context, endpoint string) (*Handler, error) {
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial gRPC endpoint: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		// Keep the snake_case field names used by the REST API
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithIncomingHeaderMatcher(matchIncomingHeader),
+		runtime.WithForwardResponseOption(setETag),
+		runtime.WithForwardResponseOption(setCreatedStatus),
+		runtime.WithErrorHandler(writeError),
+	)
+
+	if err := proto.RegisterExampleServiceHandler(ctx, mux, conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register gateway handler: %w", err)
+	}
+
+	return &Handler{
+		mux:  mux,
+		conn: conn,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Close closes the connection to the gRPC server
+func (h *Handler) Close() error {
+	return h.conn.Close()
+}
+
+// versioned is implemented by responses carrying an example version
+type versioned interface {
+	GetVersion() int64
+}
+
+// matchIncomingHeader forwards If-Match to the gRPC handler for conditional updates
+func matchIncomingHeader(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == "If-Match" {
+		return "if-match", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// setETag exposes the example version as the ETag header
+func setETag(ctx context.Context, w http.ResponseWriter, resp protov2.Message) error {
+	if v, ok := resp.(versioned); ok && v.GetVersion() > 0 {
+		w.Header().Set("ETag", etag.Format(v.GetVersion()))
+	}
+	return nil
+}
+
+// setCreatedStatus responds with 201 Created for successful creations
+func setCreatedStatus(ctx context.Context, w http.ResponseWriter, resp protov2.Message) error {
+	if _, ok := resp.(*proto.CreateExampleResponse); ok {
+		w.WriteHeader(http.StatusCreated)
+	}
+	return nil
+}
+
+// writeError renders gRPC errors with the same JSON body as the mux handler,
+// taking the error code and field violations from the status details
+func writeError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	appErr := apperrors.New(errorCode(st.Code()), st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			appErr.Code = apperrors.ErrorCode(d.GetReason())
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				appErr.Fields = append(appErr.Fields, apperrors.FieldViolation{
+					Field:       v.GetField(),
+					Description: v.GetDescription(),
+				})
+			}
+		case *errdetails.RetryInfo:
+			if delay := d.GetRetryDelay(); delay != nil {
+				w.Header().Set("Retry-After", strconv.Itoa(int(delay.AsDuration().Seconds())))
+			}
+		}
+	}
+
+	if appErr.Code == apperrors.CodePreconditionFailed {
+		// FailedPrecondition maps to 400 by default; stale versions are 412
+		appErr.Status = http.StatusPreconditionFailed
+	}
+	if appErr.Code == apperrors.CodeInternal {
+		// Do not leak transport failures to clients
+		appErr.Message = apperrors.ErrInternal.Message
+	}
+	apperrors.WriteJSON(w, appErr, r.Header.Get("X-Request-ID"))
+}
+
+// errorCode maps a gRPC status code back to an application error code
+func errorCode(code codes.Code) apperrors.ErrorCode {
+	switch code {
+	case codes.NotFound:
+		return apperrors.CodeNotFound
+	case codes.AlreadyExists:
+		return apperrors.CodeConflict
+	case codes.InvalidArgument:
+		return apperrors.CodeInvalidInput
+	case codes.Unauthenticated:
+		return apperrors.CodeUnauthorized
+	case codes.PermissionDenied:
+		return apperrors.CodeForbidden
+	case codes.DeadlineExceeded:
+		return apperrors.CodeTimeout
+	case codes.Canceled:
+		return apperrors.CodeCanceled
+	case codes.Unavailable:
+		return apperrors.CodeUnavailable
+	case codes.FailedPrecondition:
+		return apperrors.CodePreconditionFailed
+	default:
+		return apperrors.CodeInternal
+	}
+}
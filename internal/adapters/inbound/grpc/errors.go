@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"example-service/internal/application"
+	apperrors "example-service/pkg/errors"
+	"log"
+	"net/http"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorDomain identifies this service in google.rpc.ErrorInfo details
+const ErrorDomain = "example-service"
+
+// retryDelay is the back-off suggested to clients for transient failures
+const retryDelay = time.Second
+
+// mapError maps service errors to gRPC status errors using the shared
+// application error mapping. The status carries an ErrorInfo whose reason is
+// the application error code, a BadRequest with any field violations and a
+// RetryInfo when the failure is transient.
+func (h *Handler) mapError(err error) error {
+	appErr := application.MapError(err)
+	code := grpcCode(appErr.Code)
+	if code == codes.Internal {
+		log.Printf("[gRPC] internal error: %v", appErr)
+	}
+
+	st := status.New(code, appErr.Message)
+	withDetails, detailErr := st.WithDetails(errorDetails(appErr)...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// errorDetails builds the google.rpc error details for an application error
+func errorDetails(appErr *apperrors.AppError) []protoadapt.MessageV1 {
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason: string(appErr.Code),
+			Domain: ErrorDomain,
+		},
+	}
+
+	if len(appErr.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(appErr.Fields))
+		for i, f := range appErr.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Description,
+			}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	if appErr.Temporary() {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryDelay),
+		})
+	}
+
+	return details
+}
+
+// invalidArgument builds an invalid input error for a single request field
+func invalidArgument(field, description string) *apperrors.AppError {
+	return apperrors.New(apperrors.CodeInvalidInput, description, http.StatusBadRequest).
+		WithFields(apperrors.FieldViolation{Field: field, Description: description})
+}
+
+// grpcCode maps an application error code to a gRPC status code
+func grpcCode(code apperrors.ErrorCode) codes.Code {
+	switch code {
+	case apperrors.CodeNotFound:
+		return codes.NotFound
+	case apperrors.CodeConflict:
+		return codes.AlreadyExists
+	case apperrors.CodeInvalidInput:
+		return codes.InvalidArgument
+	case apperrors.CodeUnauthorized:
+		return codes.Unauthenticated
+	case apperrors.CodeForbidden:
+		return codes.PermissionDenied
+	case apperrors.CodeTimeout:
+		return codes.DeadlineExceeded
+	case apperrors.CodeCanceled:
+		return codes.Canceled
+	case apperrors.CodeUnavailable:
+		return codes.Unavailable
+	case apperrors.CodePreconditionFailed:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
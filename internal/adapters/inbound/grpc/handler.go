@@ -1,26 +1,28 @@
 package grpc
 
 import (
+	"context"
+	proto "example-service/example-service/proto"
 	"example-service/internal/application/dto"
-	"example-service/internal/domain"
 	"example-service/internal/ports/services"
-	proto "example-service/example-service/proto"
-	"context"
+	"example-service/pkg/etag"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/metadata"
 )
 
 // Handler implements the gRPC ExampleService server
 type Handler struct {
 	proto.UnimplementedExampleServiceServer
 	exampleService services.ExampleService
+	exampleWatcher services.ExampleWatcher
 }
 
-// NewHandler creates a new gRPC handler
-func NewHandler(exampleService services.ExampleService) *Handler {
+// NewHandler creates a new gRPC handler. WatchExamples is unimplemented when
+// exampleWatcher is nil.
+func NewHandler(exampleService services.ExampleService, exampleWatcher services.ExampleWatcher) *Handler {
 	return &Handler{
 		exampleService: exampleService,
+		exampleWatcher: exampleWatcher,
 	}
 }
 
@@ -28,16 +30,12 @@ func NewHandler(exampleService services.ExampleService) *Handler {
 func (h *Handler) CreateExample(ctx context.Context, req *proto.CreateExampleRequest) (*proto.CreateExampleResponse, error) {
 	// Map proto request to DTO
 	createReq := &dto.CreateExampleRequest{
-		Name: req.Name,
-	}
-
-	// Validate request
-	if createReq.Name == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+		Name:      req.Name,
+		AccountID: req.AccountId,
 	}
 
-	// Call service
-	resp, err := h.exampleService.CreateExample(createReq)
+	// Call service (validates the request)
+	resp, err := h.exampleService.CreateExample(ctx, createReq)
 	if err != nil {
 		return nil, h.mapError(err)
 	}
@@ -47,6 +45,8 @@ func (h *Handler) CreateExample(ctx context.Context, req *proto.CreateExampleReq
 		Id:        resp.ID,
 		Name:      resp.Name,
 		Status:    resp.Status,
+		AccountId: resp.AccountID,
+		Version:   resp.Version,
 		CreatedAt: resp.CreatedAt,
 		UpdatedAt: resp.UpdatedAt,
 	}, nil
@@ -55,10 +55,10 @@ func (h *Handler) CreateExample(ctx context.Context, req *proto.CreateExampleReq
 // GetExample handles example retrieval
 func (h *Handler) GetExample(ctx context.Context, req *proto.GetExampleRequest) (*proto.GetExampleResponse, error) {
 	if req.Id == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "id is required")
+		return nil, h.mapError(invalidArgument("id", "id is required"))
 	}
 
-	resp, err := h.exampleService.GetExample(req.Id)
+	resp, err := h.exampleService.GetExample(ctx, req.Id)
 	if err != nil {
 		return nil, h.mapError(err)
 	}
@@ -67,46 +67,69 @@ func (h *Handler) GetExample(ctx context.Context, req *proto.GetExampleRequest)
 		Id:        resp.ID,
 		Name:      resp.Name,
 		Status:    resp.Status,
+		AccountId: resp.AccountID,
+		Version:   resp.Version,
 		CreatedAt: resp.CreatedAt,
 		UpdatedAt: resp.UpdatedAt,
 	}, nil
 }
 
-// ListExamples handles listing all examples
+// ListExamples handles listing examples one page at a time
 func (h *Handler) ListExamples(ctx context.Context, req *proto.ListExamplesRequest) (*proto.ListExamplesResponse, error) {
-	examples, err := h.exampleService.ListExamples()
+	listReq := &dto.ListExamplesRequest{
+		PageSize:      req.PageSize,
+		PageToken:     req.PageToken,
+		Status:        req.Status,
+		NamePrefix:    req.NamePrefix,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		OrderBy:       req.OrderBy,
+		IncludeTotal:  req.IncludeTotal,
+	}
+
+	resp, err := h.exampleService.ListExamples(ctx, listReq)
 	if err != nil {
 		return nil, h.mapError(err)
 	}
 
-	protoExamples := make([]*proto.ExampleResponse, len(examples))
-	for i, ex := range examples {
+	protoExamples := make([]*proto.ExampleResponse, len(resp.Examples))
+	for i, ex := range resp.Examples {
 		protoExamples[i] = &proto.ExampleResponse{
 			Id:        ex.ID,
 			Name:      ex.Name,
 			Status:    ex.Status,
+			AccountId: ex.AccountID,
+			Version:   ex.Version,
 			CreatedAt: ex.CreatedAt,
 			UpdatedAt: ex.UpdatedAt,
 		}
 	}
 
 	return &proto.ListExamplesResponse{
-		Examples: protoExamples,
+		Examples:      protoExamples,
+		NextPageToken: resp.NextPageToken,
+		TotalCount:    resp.TotalCount,
 	}, nil
 }
 
 // UpdateExample handles example update
 func (h *Handler) UpdateExample(ctx context.Context, req *proto.UpdateExampleRequest) (*proto.UpdateExampleResponse, error) {
 	if req.Id == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "id is required")
+		return nil, h.mapError(invalidArgument("id", "id is required"))
+	}
+
+	expectedVersion, err := h.expectedVersion(ctx, req)
+	if err != nil {
+		return nil, h.mapError(err)
 	}
 
 	updateReq := &dto.UpdateExampleRequest{
-		Name:   req.Name,
-		Status: req.Status,
+		Name:            req.Name,
+		Status:          req.Status,
+		ExpectedVersion: expectedVersion,
 	}
 
-	resp, err := h.exampleService.UpdateExample(req.Id, updateReq)
+	resp, err := h.exampleService.UpdateExample(ctx, req.Id, updateReq)
 	if err != nil {
 		return nil, h.mapError(err)
 	}
@@ -115,6 +138,8 @@ func (h *Handler) UpdateExample(ctx context.Context, req *proto.UpdateExampleReq
 		Id:        resp.ID,
 		Name:      resp.Name,
 		Status:    resp.Status,
+		AccountId: resp.AccountID,
+		Version:   resp.Version,
 		CreatedAt: resp.CreatedAt,
 		UpdatedAt: resp.UpdatedAt,
 	}, nil
@@ -123,10 +148,10 @@ func (h *Handler) UpdateExample(ctx context.Context, req *proto.UpdateExampleReq
 // DeleteExample handles example deletion
 func (h *Handler) DeleteExample(ctx context.Context, req *proto.DeleteExampleRequest) (*proto.DeleteExampleResponse, error) {
 	if req.Id == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "id is required")
+		return nil, h.mapError(invalidArgument("id", "id is required"))
 	}
 
-	if err := h.exampleService.DeleteExample(req.Id); err != nil {
+	if err := h.exampleService.DeleteExample(ctx, req.Id); err != nil {
 		return nil, h.mapError(err)
 	}
 
@@ -136,17 +161,60 @@ func (h *Handler) DeleteExample(ctx context.Context, req *proto.DeleteExampleReq
 	}, nil
 }
 
-// mapError maps domain errors to gRPC status errors
-func (h *Handler) mapError(err error) error {
-	switch err {
-	case domain.ErrExampleNotFound:
-		return status.Errorf(codes.NotFound, "example not found")
-	case domain.ErrExampleAlreadyExists:
-		return status.Errorf(codes.AlreadyExists, "example already exists")
-	case domain.ErrInvalidInput:
-		return status.Errorf(codes.InvalidArgument, "invalid input")
-	default:
-		return status.Errorf(codes.Internal, "internal error: %v", err)
+// WatchExamples streams example changes until the client goes away
+func (h *Handler) WatchExamples(req *proto.WatchExamplesRequest, stream proto.ExampleService_WatchExamplesServer) error {
+	if h.exampleWatcher == nil {
+		return h.UnimplementedExampleServiceServer.WatchExamples(req, stream)
 	}
+
+	watchReq := &dto.WatchExamplesRequest{
+		IDs:         req.Ids,
+		Status:      req.Status,
+		ResumeToken: req.ResumeToken,
+	}
+	err := h.exampleWatcher.WatchExamples(stream.Context(), watchReq, func(change *dto.ExampleChange) error {
+		return stream.Send(&proto.ExampleChange{
+			Type:        changeTypes[change.Type],
+			Id:          change.ExampleID,
+			Name:        change.Name,
+			Status:      change.Status,
+			EventId:     change.EventID,
+			OccurredAt:  change.OccurredAt,
+			ResumeToken: change.ResumeToken,
+		})
+	})
+	if stream.Context().Err() != nil {
+		// The client went away
+		return nil
+	}
+	return h.mapError(err)
 }
 
+// changeTypes maps change types to their proto enum
+var changeTypes = map[string]proto.ExampleChange_Type{
+	dto.ChangeCreated: proto.ExampleChange_CREATED,
+	dto.ChangeUpdated: proto.ExampleChange_UPDATED,
+	dto.ChangeDeleted: proto.ExampleChange_DELETED,
+}
+
+// expectedVersion returns the version an update is conditional on, taken from
+// the request or, for calls proxied by the REST gateway, the if-match metadata
+func (h *Handler) expectedVersion(ctx context.Context, req *proto.UpdateExampleRequest) (*int64, error) {
+	if req.ExpectedVersion != nil {
+		return req.ExpectedVersion, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("if-match")
+	if len(values) == 0 {
+		return nil, nil
+	}
+	version, ok, err := etag.ParseIfMatch(values[0])
+	if err != nil {
+		return nil, invalidArgument("if-match", "If-Match must be an entity tag returned by the API")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &version, nil
+}
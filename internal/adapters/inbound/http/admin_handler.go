@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"example-service/internal/ports/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// AdminHandler implements the HTTP handler for event administration
+type AdminHandler struct {
+	eventAdminService services.EventAdminService
+}
+
+// NewAdminHandler creates a new event administration HTTP handler
+func NewAdminHandler(eventAdminService services.EventAdminService) *AdminHandler {
+	return &AdminHandler{
+		eventAdminService: eventAdminService,
+	}
+}
+
+// RegisterRoutes registers the event administration routes
+func (h *AdminHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/admin/dead-letters", h.ListDeadLetters).Methods("GET")
+	router.HandleFunc("/api/v1/admin/dead-letters/{id}/replay", h.ReplayDeadLetter).Methods("POST")
+	router.HandleFunc("/api/v1/admin/publish-stats", h.GetPublishStats).Methods("GET")
+}
+
+// ListDeadLetters handles GET /api/v1/admin/dead-letters
+func (h *AdminHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, r, invalidQuery("limit", "limit must be an integer", err))
+			return
+		}
+		limit = n
+	}
+
+	resp, err := h.eventAdminService.ListDeadLetters(r.Context(), limit)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ReplayDeadLetter handles POST /api/v1/admin/dead-letters/{id}/replay
+func (h *AdminHandler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.eventAdminService.ReplayDeadLetter(r.Context(), id); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// GetPublishStats handles GET /api/v1/admin/publish-stats
+func (h *AdminHandler) GetPublishStats(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.eventAdminService.GetPublishStats(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
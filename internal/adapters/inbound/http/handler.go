@@ -2,8 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"example-service/internal/application"
 	"example-service/internal/application/dto"
 	"example-service/internal/ports/services"
+	apperrors "example-service/pkg/errors"
+	"example-service/pkg/etag"
+	"log"
 	"net/http"
 	"strconv"
 
@@ -35,17 +39,18 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 func (h *Handler) CreateExample(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateExampleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.Wrap(apperrors.CodeInvalidInput, "Invalid request body", http.StatusBadRequest, err))
 		return
 	}
 
-	resp, err := h.exampleService.CreateExample(&req)
+	resp, err := h.exampleService.CreateExample(r.Context(), &req)
 	if err != nil {
-		h.handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Format(resp.Version))
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(resp)
 }
@@ -55,30 +60,57 @@ func (h *Handler) GetExample(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		writeError(w, r, apperrors.Wrap(apperrors.CodeInvalidInput, "Invalid ID", http.StatusBadRequest, err))
 		return
 	}
 
-	resp, err := h.exampleService.GetExample(id)
+	resp, err := h.exampleService.GetExample(r.Context(), id)
 	if err != nil {
-		h.handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Format(resp.Version))
 	json.NewEncoder(w).Encode(resp)
 }
 
 // ListExamples handles GET /api/v1/examples
 func (h *Handler) ListExamples(w http.ResponseWriter, r *http.Request) {
-	examples, err := h.exampleService.ListExamples()
+	q := r.URL.Query()
+	req := dto.ListExamplesRequest{
+		PageToken:     q.Get("page_token"),
+		Status:        q.Get("status"),
+		NamePrefix:    q.Get("name_prefix"),
+		CreatedAfter:  q.Get("created_after"),
+		CreatedBefore: q.Get("created_before"),
+		OrderBy:       q.Get("order_by"),
+	}
+	if v := q.Get("page_size"); v != "" {
+		pageSize, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			writeError(w, r, invalidQuery("page_size", "page_size must be an integer", err))
+			return
+		}
+		req.PageSize = int32(pageSize)
+	}
+	if v := q.Get("include_total"); v != "" {
+		includeTotal, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, r, invalidQuery("include_total", "include_total must be a boolean", err))
+			return
+		}
+		req.IncludeTotal = includeTotal
+	}
+
+	resp, err := h.exampleService.ListExamples(r.Context(), &req)
 	if err != nil {
-		h.handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(examples)
+	json.NewEncoder(w).Encode(resp)
 }
 
 // UpdateExample handles PUT /api/v1/examples/{id}
@@ -86,23 +118,34 @@ func (h *Handler) UpdateExample(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		writeError(w, r, apperrors.Wrap(apperrors.CodeInvalidInput, "Invalid ID", http.StatusBadRequest, err))
 		return
 	}
 
 	var req dto.UpdateExampleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.Wrap(apperrors.CodeInvalidInput, "Invalid request body", http.StatusBadRequest, err))
 		return
 	}
 
-	resp, err := h.exampleService.UpdateExample(id, &req)
+	// Honour If-Match for optimistic concurrency control
+	version, ok, err := etag.ParseIfMatch(r.Header.Get("If-Match"))
 	if err != nil {
-		h.handleError(w, err)
+		writeError(w, r, invalidQuery("If-Match", "If-Match must be an entity tag returned by the API", err))
+		return
+	}
+	if ok {
+		req.ExpectedVersion = &version
+	}
+
+	resp, err := h.exampleService.UpdateExample(r.Context(), id, &req)
+	if err != nil {
+		handleError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Format(resp.Version))
 	json.NewEncoder(w).Encode(resp)
 }
 
@@ -111,21 +154,37 @@ func (h *Handler) DeleteExample(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		writeError(w, r, apperrors.Wrap(apperrors.CodeInvalidInput, "Invalid ID", http.StatusBadRequest, err))
 		return
 	}
 
-	if err := h.exampleService.DeleteExample(id); err != nil {
-		h.handleError(w, err)
+	if err := h.exampleService.DeleteExample(r.Context(), id); err != nil {
+		handleError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// handleError handles errors and returns appropriate HTTP responses
-func (h *Handler) handleError(w http.ResponseWriter, err error) {
-	// In a real implementation, you would map domain errors to HTTP status codes
-	http.Error(w, err.Error(), http.StatusInternalServerError)
+// handleError maps service errors to HTTP responses
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(w, r, application.MapError(err))
+}
+
+// invalidQuery builds an invalid input error for a malformed query parameter or header
+func invalidQuery(field, description string, err error) *apperrors.AppError {
+	return apperrors.Wrap(apperrors.CodeInvalidInput, description, http.StatusBadRequest, err).
+		WithFields(apperrors.FieldViolation{Field: field, Description: description})
 }
 
+// writeError writes a structured JSON error, logging server-side failures
+func writeError(w http.ResponseWriter, r *http.Request, appErr *apperrors.AppError) {
+	requestID := RequestIDFromContext(r.Context())
+	if appErr.Status >= http.StatusInternalServerError {
+		log.Printf("[HTTP] request_id=%s %s %s: %v", requestID, r.Method, r.URL.Path, appErr)
+	}
+	if appErr.Temporary() {
+		w.Header().Set("Retry-After", "1")
+	}
+	apperrors.WriteJSON(w, appErr, requestID)
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"example-service/internal/application"
+	"example-service/internal/application/dto"
+	"example-service/internal/ports/services"
+	apperrors "example-service/pkg/errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// streamWriteTimeout is how long a client may take to accept a write before
+// its stream is closed
+const streamWriteTimeout = 30 * time.Second
+
+// streamRetry is how long EventSource clients wait before reconnecting
+const streamRetry = 2 * time.Second
+
+// StreamHandler serves example changes as Server-Sent Events
+type StreamHandler struct {
+	watcher   services.ExampleWatcher
+	heartbeat time.Duration
+}
+
+// NewStreamHandler creates a new change stream HTTP handler that sends a
+// heartbeat comment at the given interval to keep idle connections open
+func NewStreamHandler(watcher services.ExampleWatcher, heartbeat time.Duration) *StreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &StreamHandler{
+		watcher:   watcher,
+		heartbeat: heartbeat,
+	}
+}
+
+// RegisterRoutes registers the change stream route. It must be registered
+// before the example routes, which would otherwise take "stream" for an id.
+func (h *StreamHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/examples/stream", h.StreamExamples).Methods("GET")
+}
+
+// StreamExamples handles GET /api/v1/examples/stream. Each change is sent as
+// an event named after the change type, with the change as JSON data and its
+// resume token as the event id, so EventSource clients resume on reconnect
+// through the Last-Event-ID header. The ids and status query parameters
+// filter the changes. The request is validated before the response starts,
+// so invalid requests get a plain error response.
+func (h *StreamHandler) StreamExamples(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	req := dto.WatchExamplesRequest{
+		Status:      q.Get("status"),
+		ResumeToken: r.Header.Get("Last-Event-ID"),
+	}
+	if req.ResumeToken == "" {
+		// EventSource cannot set headers, so the first connection may pass
+		// the token as a query parameter instead
+		req.ResumeToken = q.Get("last_event_id")
+	}
+	for _, v := range q["ids"] {
+		for _, s := range strings.Split(v, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				writeError(w, r, invalidQuery("ids", "ids must be a comma separated list of integers", err))
+				return
+			}
+			req.IDs = append(req.IDs, id)
+		}
+	}
+
+	if err := h.watcher.ValidateWatch(&req); err != nil {
+		writeError(w, r, application.MapError(err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	stream := &eventStream{w: w, rc: http.NewResponseController(w)}
+	// Keep-alive connections must not inherit the write deadline
+	defer stream.rc.SetWriteDeadline(time.Time{})
+
+	// Start the response right away so clients and proxies see the stream
+	// open before the first change
+	if err := stream.open(); err != nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		h.sendHeartbeats(ctx, cancel, stream)
+	}()
+
+	err := h.watcher.WatchExamples(ctx, &req, stream.send)
+	// The stream ended because the client left or could not be written to
+	aborted := ctx.Err() != nil || stream.failed()
+	cancel()
+	wg.Wait()
+	if err == nil || aborted {
+		return
+	}
+
+	appErr := application.MapError(err)
+	requestID := RequestIDFromContext(r.Context())
+	if appErr.Status >= http.StatusInternalServerError {
+		log.Printf("[HTTP] request_id=%s %s %s: %v", requestID, r.Method, r.URL.Path, appErr)
+	}
+	stream.sendError(appErr, requestID)
+}
+
+// sendHeartbeats sends a comment every heartbeat interval until ctx is done.
+// A failed write cancels the stream.
+func (h *StreamHandler) sendHeartbeats(ctx context.Context, cancel context.CancelFunc, stream *eventStream) {
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := stream.write(": heartbeat\n\n"); err != nil {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// eventStream writes Server-Sent Events to a response
+type eventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+
+	mu  sync.Mutex
+	err error
+}
+
+// open sends the response headers and the reconnection delay
+func (s *eventStream) open() error {
+	h := s.w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+	return s.write(fmt.Sprintf("retry: %d\n\n", streamRetry.Milliseconds()))
+}
+
+// send writes a change as an event
+func (s *eventStream) send(c *dto.ExampleChange) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode change: %w", err)
+	}
+	return s.write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", c.ResumeToken, c.Type, data))
+}
+
+// sendError writes an error event before the stream closes
+func (s *eventStream) sendError(appErr *apperrors.AppError, requestID string) {
+	data, _ := json.Marshal(apperrors.ErrorResponse{
+		Code:      appErr.Code,
+		Message:   appErr.Message,
+		RequestID: requestID,
+		Fields:    appErr.Fields,
+	})
+	_ = s.write(fmt.Sprintf("event: error\ndata: %s\n\n", data))
+}
+
+// failed reports whether a write failed
+func (s *eventStream) failed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err != nil
+}
+
+// write writes and flushes a chunk of the stream. Once a write fails every
+// later write returns the same error.
+func (s *eventStream) write(chunk string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+
+	// A client that stops reading is dropped rather than holding the
+	// connection forever; not every ResponseWriter supports deadlines
+	_ = s.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if _, err := s.w.Write([]byte(chunk)); err != nil {
+		s.err = fmt.Errorf("failed to write event: %w", err)
+		return s.err
+	}
+	if err := s.rc.Flush(); err != nil {
+		s.err = fmt.Errorf("failed to flush event: %w", err)
+		return s.err
+	}
+	return nil
+}
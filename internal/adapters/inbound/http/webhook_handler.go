@@ -0,0 +1,134 @@
+package http
+
+import (
+	"encoding/json"
+	"example-service/internal/application/dto"
+	"example-service/internal/ports/services"
+	apperrors "example-service/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// WebhookHandler implements the HTTP handler for webhook subscriptions
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook HTTP handler
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// RegisterRoutes registers the webhook routes
+func (h *WebhookHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/webhooks", h.CreateWebhook).Methods("POST")
+	router.HandleFunc("/api/v1/webhooks", h.ListWebhooks).Methods("GET")
+	router.HandleFunc("/api/v1/webhooks/{id}", h.GetWebhook).Methods("GET")
+	router.HandleFunc("/api/v1/webhooks/{id}", h.DeleteWebhook).Methods("DELETE")
+	router.HandleFunc("/api/v1/webhooks/{id}/deliveries", h.ListDeliveries).Methods("GET")
+}
+
+// CreateWebhook handles POST /api/v1/webhooks
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.Wrap(apperrors.CodeInvalidInput, "Invalid request body", http.StatusBadRequest, err))
+		return
+	}
+
+	resp, err := h.webhookService.CreateWebhook(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ListWebhooks handles GET /api/v1/webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.webhookService.ListWebhooks(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetWebhook handles GET /api/v1/webhooks/{id}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.webhookService.GetWebhook(r.Context(), id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), id); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /api/v1/webhooks/{id}/deliveries
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, r, invalidQuery("limit", "limit must be an integer", err))
+			return
+		}
+		limit = n
+	}
+
+	resp, err := h.webhookService.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// pathID parses the id path variable, writing an error response when it is
+// malformed
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, r, apperrors.Wrap(apperrors.CodeInvalidInput, "Invalid ID", http.StatusBadRequest, err))
+		return 0, false
+	}
+	return id, true
+}
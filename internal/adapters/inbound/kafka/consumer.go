@@ -0,0 +1,231 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/config"
+	"example-service/internal/domain"
+	"example-service/internal/ports/services"
+	"example-service/pkg/cloudevents"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// maxRetryBackoff caps the delay between attempts at one event
+const maxRetryBackoff = 30 * time.Second
+
+// Kafka protocol binding headers
+const (
+	contentTypeHeader = "content-type"
+	attributePrefix   = "ce_"
+)
+
+// Consumer reads CloudEvents from Kafka as part of a consumer group and
+// passes them to the event handler port. Processing is at-least-once: an
+// offset is committed only after its event was handled or dead-lettered.
+// Failed events are retried with exponential backoff and then written to
+// the dead-letter topic, so one bad event cannot stall its partition.
+type Consumer struct {
+	client          *kgo.Client
+	handler         services.EventHandler
+	deadLetterTopic string
+	maxAttempts     int
+	retryBackoff    time.Duration
+}
+
+// NewConsumer creates a consumer for the configured topics
+func NewConsumer(cfg config.KafkaConfig, handler services.EventHandler) (*Consumer, error) {
+	cc := cfg.Consumer
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	if len(cc.Topics) == 0 || cc.GroupID == "" {
+		return nil, errors.New("kafka: consumer topics and group id are required")
+	}
+	if cc.MaxAttempts <= 0 {
+		cc.MaxAttempts = 1
+	}
+	if cc.RetryBackoff <= 0 {
+		cc.RetryBackoff = 500 * time.Millisecond
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.ConsumerGroup(cc.GroupID),
+		kgo.ConsumeTopics(cc.Topics...),
+		kgo.AutoCommitMarks(),
+	}
+	if cfg.ClientID != "" {
+		opts = append(opts, kgo.ClientID(cfg.ClientID))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+
+	return &Consumer{
+		client:          client,
+		handler:         handler,
+		deadLetterTopic: cc.DeadLetterTopic,
+		maxAttempts:     cc.MaxAttempts,
+		retryBackoff:    cc.RetryBackoff,
+	}, nil
+}
+
+// Run consumes events until ctx is cancelled
+func (c *Consumer) Run(ctx context.Context) {
+	for {
+		fetches := c.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			return
+		}
+		fetches.EachError(func(topic string, partition int32, err error) {
+			log.Printf("[KafkaConsumer] fetch from %s/%d failed: %v", topic, partition, err)
+		})
+
+		iter := fetches.RecordIter()
+		for !iter.Done() {
+			record := iter.Next()
+			if !c.process(ctx, record) {
+				return
+			}
+			c.client.MarkCommitRecords(record)
+		}
+	}
+}
+
+// Close commits the offsets of processed events and leaves the group
+func (c *Consumer) Close() {
+	c.client.Close()
+}
+
+// process handles one record, retrying and finally dead-lettering it. It
+// returns false if ctx was cancelled before the record was dealt with.
+func (c *Consumer) process(ctx context.Context, record *kgo.Record) bool {
+	event, err := decodeRecord(record)
+	if err != nil {
+		return c.deadLetter(ctx, record, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.handler.HandleEvent(ctx, event)
+		if err == nil {
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		log.Printf("[KafkaConsumer] %s event %s failed (attempt %d/%d): %v", event.Type, event.ID, attempt, c.maxAttempts, err)
+		if errors.Is(err, domain.ErrInvalidInput) || attempt >= c.maxAttempts {
+			return c.deadLetter(ctx, record, err)
+		}
+		if !sleep(ctx, c.backoff(attempt)) {
+			return false
+		}
+	}
+}
+
+// deadLetter copies a record to the dead-letter topic with the failure
+// recorded in headers. The partition is blocked until the write succeeds,
+// since skipping the record would lose it.
+func (c *Consumer) deadLetter(ctx context.Context, record *kgo.Record, cause error) bool {
+	if c.deadLetterTopic == "" {
+		log.Printf("[KafkaConsumer] dropping record %s/%d@%d: %v", record.Topic, record.Partition, record.Offset, cause)
+		return true
+	}
+
+	dlt := &kgo.Record{
+		Topic: c.deadLetterTopic,
+		Key:   record.Key,
+		Value: record.Value,
+		Headers: append(append([]kgo.RecordHeader(nil), record.Headers...),
+			kgo.RecordHeader{Key: "dlt-error", Value: []byte(cause.Error())},
+			kgo.RecordHeader{Key: "dlt-topic", Value: []byte(record.Topic)},
+			kgo.RecordHeader{Key: "dlt-partition", Value: []byte(strconv.Itoa(int(record.Partition)))},
+			kgo.RecordHeader{Key: "dlt-offset", Value: []byte(strconv.FormatInt(record.Offset, 10))},
+		),
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.client.ProduceSync(ctx, dlt).FirstErr()
+		if err == nil {
+			log.Printf("[KafkaConsumer] dead-lettered record %s/%d@%d: %v", record.Topic, record.Partition, record.Offset, cause)
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		log.Printf("[KafkaConsumer] failed to dead-letter record %s/%d@%d: %v", record.Topic, record.Partition, record.Offset, err)
+		if !sleep(ctx, c.backoff(attempt)) {
+			return false
+		}
+	}
+}
+
+// backoff returns the exponential retry delay for the given attempt
+func (c *Consumer) backoff(attempt int) time.Duration {
+	delay := c.retryBackoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	return delay
+}
+
+// sleep waits for d, returning false if ctx is cancelled first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// decodeRecord reads the CloudEvent in a record, in either content mode
+func decodeRecord(record *kgo.Record) (*domain.InboundEvent, error) {
+	attrs := make(map[string]string)
+	var contentType string
+	for _, h := range record.Headers {
+		if h.Key == contentTypeHeader {
+			contentType = string(h.Value)
+		} else if name, ok := strings.CutPrefix(h.Key, attributePrefix); ok {
+			attrs[name] = string(h.Value)
+		}
+	}
+
+	var (
+		ce  *cloudevents.Event
+		err error
+	)
+	if cloudevents.IsStructured(contentType) {
+		ce, err = cloudevents.UnmarshalStructured(record.Value)
+	} else {
+		ce, err = cloudevents.FromBinary(attrs, contentType, record.Value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	return &domain.InboundEvent{
+		ID:          ce.ID,
+		Source:      ce.Source,
+		Type:        ce.Type,
+		Subject:     ce.Subject,
+		Version:     ce.DataVersion,
+		Time:        ce.Time,
+		ContentType: ce.DataContentType,
+		Data:        ce.Data,
+	}, nil
+}
@@ -0,0 +1,273 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/config"
+	"example-service/internal/domain"
+	"example-service/internal/ports/services"
+	"example-service/pkg/cloudevents"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Stream entry fields written by the Redis event publisher
+const (
+	contentTypeField = "content-type"
+	dataField        = "data"
+	attributePrefix  = "ce_"
+)
+
+// Read settings
+const (
+	readCount   = 100
+	readBlock   = time.Second
+	reclaimSize = 100
+)
+
+// Consumer reads CloudEvents from Redis Streams as part of a consumer group
+// and passes them to the event handler port. An entry is acknowledged with
+// XACK once handled; failed entries stay pending and are reclaimed for
+// another attempt after ClaimMinIdle, including entries left behind by a
+// crashed instance. After MaxAttempts deliveries an entry is moved to the
+// dead-letter stream.
+type Consumer struct {
+	client           *redis.Client
+	handler          services.EventHandler
+	streams          []string
+	group            string
+	name             string
+	deadLetterStream string
+	maxAttempts      int64
+	claimMinIdle     time.Duration
+	block            time.Duration
+}
+
+// NewConsumer creates a consumer for the configured streams. The client is
+// owned by the caller.
+func NewConsumer(client *redis.Client, cfg config.RedisStreamConfig, handler services.EventHandler) (*Consumer, error) {
+	if len(cfg.ConsumerStreams) == 0 || cfg.ConsumerGroup == "" || cfg.ConsumerName == "" {
+		return nil, errors.New("redis: consumer streams, group and name are required")
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.ClaimMinIdle <= 0 {
+		cfg.ClaimMinIdle = 30 * time.Second
+	}
+
+	return &Consumer{
+		client:           client,
+		handler:          handler,
+		streams:          cfg.ConsumerStreams,
+		group:            cfg.ConsumerGroup,
+		name:             cfg.ConsumerName,
+		deadLetterStream: cfg.DeadLetterStream,
+		maxAttempts:      int64(cfg.MaxAttempts),
+		claimMinIdle:     cfg.ClaimMinIdle,
+		// Wake up often enough to reclaim entries as soon as they are due
+		block: min(readBlock, cfg.ClaimMinIdle),
+	}, nil
+}
+
+// Run consumes events until ctx is cancelled
+func (c *Consumer) Run(ctx context.Context) {
+	for _, stream := range c.streams {
+		err := c.client.XGroupCreateMkStream(ctx, stream, c.group, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			log.Printf("[RedisConsumer] failed to create group %s on %s: %v", c.group, stream, err)
+		}
+	}
+
+	for ctx.Err() == nil {
+		for _, stream := range c.streams {
+			if err := c.reclaim(ctx, stream); err != nil && ctx.Err() == nil {
+				log.Printf("[RedisConsumer] failed to reclaim pending entries on %s: %v", stream, err)
+			}
+		}
+		if err := c.readNew(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[RedisConsumer] failed to read streams: %v", err)
+			sleep(ctx, readBlock)
+		}
+	}
+}
+
+// Close releases resources; the Redis client is closed by its owner
+func (c *Consumer) Close() {}
+
+// readNew handles entries not yet delivered to the group
+func (c *Consumer) readNew(ctx context.Context) error {
+	args := make([]string, 0, 2*len(c.streams))
+	args = append(args, c.streams...)
+	for range c.streams {
+		args = append(args, ">")
+	}
+
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.group,
+		Consumer: c.name,
+		Streams:  args,
+		Count:    readCount,
+		Block:    c.block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, s := range streams {
+		for _, msg := range s.Messages {
+			c.process(ctx, s.Stream, msg)
+		}
+	}
+	return nil
+}
+
+// reclaim takes over entries that have been pending for at least
+// ClaimMinIdle and tries them again, dead-lettering those that have used up
+// their attempts
+func (c *Consumer) reclaim(ctx context.Context, stream string) error {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  c.group,
+		Start:  "-",
+		End:    "+",
+		Count:  reclaimSize,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	deliveries := make(map[string]int64)
+	var ids []string
+	for _, p := range pending {
+		if p.Idle >= c.claimMinIdle {
+			ids = append(ids, p.ID)
+			deliveries[p.ID] = p.RetryCount
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	claimed, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    c.group,
+		Consumer: c.name,
+		MinIdle:  c.claimMinIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range claimed {
+		if deliveries[msg.ID] >= c.maxAttempts {
+			c.deadLetter(ctx, stream, msg, fmt.Errorf("gave up after %d attempts", deliveries[msg.ID]))
+			continue
+		}
+		c.process(ctx, stream, msg)
+	}
+	return nil
+}
+
+// process handles one entry. Failed entries are left pending for reclaim,
+// except permanent failures, which are dead-lettered immediately.
+func (c *Consumer) process(ctx context.Context, stream string, msg redis.XMessage) {
+	event, err := decodeMessage(msg)
+	if err != nil {
+		c.deadLetter(ctx, stream, msg, err)
+		return
+	}
+
+	if err := c.handler.HandleEvent(ctx, event); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[RedisConsumer] %s event %s from %s failed: %v", event.Type, event.ID, stream, err)
+		if errors.Is(err, domain.ErrInvalidInput) {
+			c.deadLetter(ctx, stream, msg, err)
+		}
+		return
+	}
+
+	c.ack(ctx, stream, msg.ID)
+}
+
+// deadLetter copies an entry to the dead-letter stream and acknowledges it.
+// If the copy fails the entry stays pending and is dead-lettered on a later
+// reclaim.
+func (c *Consumer) deadLetter(ctx context.Context, stream string, msg redis.XMessage, cause error) {
+	if c.deadLetterStream != "" {
+		values := make(map[string]interface{}, len(msg.Values)+3)
+		for k, v := range msg.Values {
+			values[k] = v
+		}
+		values["dlt-error"] = cause.Error()
+		values["dlt-stream"] = stream
+		values["dlt-id"] = msg.ID
+
+		if err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: c.deadLetterStream, Values: values}).Err(); err != nil {
+			log.Printf("[RedisConsumer] failed to dead-letter entry %s/%s: %v", stream, msg.ID, err)
+			return
+		}
+	}
+
+	log.Printf("[RedisConsumer] dead-lettered entry %s/%s: %v", stream, msg.ID, cause)
+	c.ack(ctx, stream, msg.ID)
+}
+
+func (c *Consumer) ack(ctx context.Context, stream, id string) {
+	if err := c.client.XAck(ctx, stream, c.group, id).Err(); err != nil {
+		log.Printf("[RedisConsumer] failed to acknowledge entry %s/%s: %v", stream, id, err)
+	}
+}
+
+// sleep waits for d or until ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// decodeMessage reads the CloudEvent in a stream entry
+func decodeMessage(msg redis.XMessage) (*domain.InboundEvent, error) {
+	attrs := make(map[string]string)
+	var contentType, data string
+	for key, value := range msg.Values {
+		s, _ := value.(string)
+		switch {
+		case key == contentTypeField:
+			contentType = s
+		case key == dataField:
+			data = s
+		case strings.HasPrefix(key, attributePrefix):
+			attrs[strings.TrimPrefix(key, attributePrefix)] = s
+		}
+	}
+
+	ce, err := cloudevents.FromBinary(attrs, contentType, []byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	return &domain.InboundEvent{
+		ID:          ce.ID,
+		Source:      ce.Source,
+		Type:        ce.Type,
+		Subject:     ce.Subject,
+		Version:     ce.DataVersion,
+		Time:        ce.Time,
+		ContentType: ce.DataContentType,
+		Data:        ce.Data,
+	}, nil
+}
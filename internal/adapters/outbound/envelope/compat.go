@@ -0,0 +1,54 @@
+package envelope
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrIncompatible is returned when a new schema version breaks an older one
+var ErrIncompatible = errors.New("incompatible event schema")
+
+// CheckCompatible reports whether consumers of older can read messages
+// written with newer, in both the protobuf and JSON encodings. Every field of
+// older must either keep its number, name, kind and cardinality in newer, or
+// be removed with its number and name reserved. Fields may be added freely.
+func CheckCompatible(older, newer protoreflect.MessageDescriptor) error {
+	var problems []error
+	fields := older.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		old := fields.Get(i)
+		cur := newer.Fields().ByNumber(old.Number())
+		if cur == nil {
+			if !newer.ReservedRanges().Has(old.Number()) || !newer.ReservedNames().Has(old.Name()) {
+				problems = append(problems, fmt.Errorf("field %d (%s) removed without reserving its number and name", old.Number(), old.Name()))
+			}
+			continue
+		}
+		if cur.Name() != old.Name() {
+			problems = append(problems, fmt.Errorf("field %d renamed from %s to %s", old.Number(), old.Name(), cur.Name()))
+		}
+		if cur.Kind() != old.Kind() {
+			problems = append(problems, fmt.Errorf("field %d (%s) changed type from %s to %s", old.Number(), old.Name(), old.Kind(), cur.Kind()))
+		} else if (old.Kind() == protoreflect.MessageKind || old.Kind() == protoreflect.EnumKind) && fullName(cur) != fullName(old) {
+			problems = append(problems, fmt.Errorf("field %d (%s) changed type from %s to %s", old.Number(), old.Name(), fullName(old), fullName(cur)))
+		}
+		if cur.Cardinality() != old.Cardinality() {
+			problems = append(problems, fmt.Errorf("field %d (%s) changed cardinality from %s to %s", old.Number(), old.Name(), old.Cardinality(), cur.Cardinality()))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s: %w", ErrIncompatible, newer.FullName(), errors.Join(problems...))
+	}
+	return nil
+}
+
+// fullName returns the message or enum type name of a field
+func fullName(fd protoreflect.FieldDescriptor) protoreflect.FullName {
+	if fd.Kind() == protoreflect.EnumKind {
+		return fd.Enum().FullName()
+	}
+	return fd.Message().FullName()
+}
@@ -0,0 +1,156 @@
+package envelope
+
+import (
+	"encoding/json"
+	"example-service/internal/domain"
+	"example-service/pkg/cloudevents"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Data encodings
+const (
+	// FormatJSON encodes data with the protobuf JSON mapping
+	FormatJSON = "json"
+	// FormatProtobuf encodes data in the protobuf binary format
+	FormatProtobuf = "protobuf"
+)
+
+// Config configures an Encoder
+type Config struct {
+	// Source identifies this service
+	Source string
+	// SchemaBase is the URI the data schema of each event type is published under
+	SchemaBase string
+	// Format is FormatJSON or FormatProtobuf; empty means JSON
+	Format string
+	// Registry defines the event payloads; nil means DefaultRegistry
+	Registry *Registry
+}
+
+// Encoder wraps domain events in CloudEvents envelopes. Every outbound event
+// adapter encodes through it so all transports emit the same attributes.
+type Encoder struct {
+	source     string
+	schemaBase string
+	protobuf   bool
+	registry   *Registry
+}
+
+// NewEncoder creates an encoder, failing if the registered event versions
+// are not compatible with each other
+func NewEncoder(cfg Config) (*Encoder, error) {
+	switch cfg.Format {
+	case FormatJSON, FormatProtobuf, "":
+	default:
+		return nil, fmt.Errorf("unknown event format %q", cfg.Format)
+	}
+	if cfg.Registry == nil {
+		cfg.Registry = DefaultRegistry()
+	}
+	if err := cfg.Registry.Check(); err != nil {
+		return nil, err
+	}
+
+	return &Encoder{
+		source:     cfg.Source,
+		schemaBase: cfg.SchemaBase,
+		protobuf:   cfg.Format == FormatProtobuf,
+		registry:   cfg.Registry,
+	}, nil
+}
+
+// Encode builds the envelope for a domain event. The subject is the example
+// id and the data is the payload converted to the registered message for the
+// event type and version.
+func (e *Encoder) Encode(event *domain.Event) (*cloudevents.Event, error) {
+	version := event.Version
+	if version == 0 {
+		version = 1
+	}
+
+	msg, err := e.payloadMessage(event, version)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := cloudevents.JSONContentType
+	var data []byte
+	if e.protobuf {
+		contentType = cloudevents.ProtobufContentType
+		data, err = proto.Marshal(msg)
+	} else {
+		data, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	return &cloudevents.Event{
+		SpecVersion:     cloudevents.SpecVersion,
+		ID:              event.ID,
+		Source:          e.source,
+		Type:            event.Type,
+		Subject:         strconv.FormatInt(event.AggregateID, 10),
+		Time:            event.Timestamp.UTC(),
+		DataContentType: contentType,
+		DataSchema:      e.DataSchema(event.Type, version),
+		DataVersion:     version,
+		Data:            data,
+	}, nil
+}
+
+// Decode returns the payload of an envelope as its registered message
+func (e *Encoder) Decode(ce *cloudevents.Event) (proto.Message, error) {
+	version := ce.DataVersion
+	if version == 0 {
+		version = 1
+	}
+	mt, err := e.registry.Lookup(ce.Type, version)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := mt.New().Interface()
+	if cloudevents.IsJSON(ce.DataContentType) {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(ce.Data, msg)
+	} else {
+		err = proto.Unmarshal(ce.Data, msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %w", ce.Type, err)
+	}
+	return msg, nil
+}
+
+// DataSchema returns the schema URI of an event type version
+func (e *Encoder) DataSchema(eventType string, version int) string {
+	return fmt.Sprintf("%s:%s:v%d", e.schemaBase, eventType, version)
+}
+
+// payloadMessage converts a domain payload, or its JSON form as stored in
+// the outbox, to the registered message. The payload json names must match
+// the message fields; unknown fields are an error so schema drift is caught.
+func (e *Encoder) payloadMessage(event *domain.Event, version int) (proto.Message, error) {
+	mt, err := e.registry.Lookup(event.Type, version)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := mt.New().Interface()
+	if event.Payload == nil {
+		return msg, nil
+	}
+
+	raw, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+	if err := protojson.Unmarshal(raw, msg); err != nil {
+		return nil, fmt.Errorf("event payload does not match %s: %w", mt.Descriptor().FullName(), err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,114 @@
+package envelope
+
+import (
+	"errors"
+	eventspb "example-service/example-service/proto"
+	"example-service/internal/domain"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrUnknownSchema is returned for event types or versions that are not registered
+var ErrUnknownSchema = errors.New("unknown event schema")
+
+// schemaKey identifies one version of an event type
+type schemaKey struct {
+	eventType string
+	version   int
+}
+
+// Registry maps event types and versions to the protobuf messages that
+// define their payloads
+type Registry struct {
+	schemas map[schemaKey]protoreflect.MessageType
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		schemas: make(map[schemaKey]protoreflect.MessageType),
+	}
+}
+
+// DefaultRegistry returns a registry with every version of the events this
+// service publishes
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, schema := range []struct {
+		eventType string
+		version   int
+		msg       proto.Message
+	}{
+		{domain.EventTypeExampleCreated, 1, &eventspb.ExampleCreated{}},
+		{domain.EventTypeExampleUpdated, 1, &eventspb.ExampleUpdated{}},
+		{domain.EventTypeExampleDeleted, 1, &eventspb.ExampleDeleted{}},
+		{domain.EventTypeExampleCreated, 2, &eventspb.ExampleCreatedV2{}},
+		{domain.EventTypeExampleUpdated, 2, &eventspb.ExampleUpdatedV2{}},
+		{domain.EventTypeExampleDeleted, 2, &eventspb.ExampleDeletedV2{}},
+	} {
+		if err := r.Register(schema.eventType, schema.version, schema.msg); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register adds the message defining version of eventType. A version can be
+// registered only once.
+func (r *Registry) Register(eventType string, version int, msg proto.Message) error {
+	key := schemaKey{eventType, version}
+	if version < 1 {
+		return fmt.Errorf("event schema %s: version must be positive", eventType)
+	}
+	if _, ok := r.schemas[key]; ok {
+		return fmt.Errorf("event schema %s v%d is already registered", eventType, version)
+	}
+	r.schemas[key] = msg.ProtoReflect().Type()
+	return nil
+}
+
+// Lookup returns the message type for a version of an event type
+func (r *Registry) Lookup(eventType string, version int) (protoreflect.MessageType, error) {
+	mt, ok := r.schemas[schemaKey{eventType, version}]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnknownSchema, eventType, version)
+	}
+	return mt, nil
+}
+
+// Versions returns the registered versions of an event type in ascending order
+func (r *Registry) Versions(eventType string) []int {
+	var versions []int
+	for key := range r.schemas {
+		if key.eventType == eventType {
+			versions = append(versions, key.version)
+		}
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// Check verifies that every version of each event type is compatible with
+// the version before it
+func (r *Registry) Check() error {
+	types := make(map[string]bool)
+	for key := range r.schemas {
+		types[key.eventType] = true
+	}
+
+	var errs []error
+	for eventType := range types {
+		versions := r.Versions(eventType)
+		for i := 1; i < len(versions); i++ {
+			older := r.schemas[schemaKey{eventType, versions[i-1]}].Descriptor()
+			newer := r.schemas[schemaKey{eventType, versions[i]}].Descriptor()
+			if err := CheckCompatible(older, newer); err != nil {
+				errs = append(errs, fmt.Errorf("%s v%d -> v%d: %w", eventType, versions[i-1], versions[i], err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,270 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned when subscribing to or publishing on a closed bus
+var ErrClosed = errors.New("event bus is closed")
+
+// Mode is how a subscriber receives events
+type Mode int
+
+const (
+	// Sync subscribers run inside Publish, which returns their errors
+	Sync Mode = iota
+	// Async subscribers run on their own goroutine, fed by a queue; Publish
+	// does not wait for them
+	Async
+)
+
+// String returns the name of the mode
+func (m Mode) String() string {
+	if m == Async {
+		return "async"
+	}
+	return "sync"
+}
+
+// Handler reacts to an event. The event is shared between subscribers and
+// must not be modified.
+type Handler func(ctx context.Context, event *domain.Event) error
+
+// Subscriber describes a subscription to the bus
+type Subscriber struct {
+	// Name identifies the subscriber in logs and stats
+	Name string
+	// EventTypes limits the subscription to these event types; empty means
+	// every event
+	EventTypes []string
+	Mode       Mode
+	// QueueSize is the number of events an async subscriber buffers before
+	// new ones are dropped; defaults to 1024
+	QueueSize int
+	Handler   Handler
+}
+
+// subscription is a registered subscriber and its counters
+type subscription struct {
+	Subscriber
+	types map[string]bool
+	queue chan queuedEvent
+
+	delivered atomic.Uint64
+	failed    atomic.Uint64
+	panics    atomic.Uint64
+	dropped   atomic.Uint64
+	lastError atomic.Value
+}
+
+// queuedEvent is an event waiting for an async subscriber
+type queuedEvent struct {
+	ctx   context.Context
+	event *domain.Event
+}
+
+// EventBus is an in-process event publisher that fans events out to
+// subscribers. A failing or panicking subscriber never affects the others.
+type EventBus struct {
+	mu            sync.RWMutex
+	closed        bool
+	subscriptions []*subscription
+	wg            sync.WaitGroup
+}
+
+// NewEventBus creates an event bus without subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+var (
+	_ external.EventPublisher          = (*EventBus)(nil)
+	_ external.SubscriberStatsReporter = (*EventBus)(nil)
+)
+
+// Subscribe registers a subscriber. Subscriber names must be unique.
+func (b *EventBus) Subscribe(s Subscriber) error {
+	if s.Name == "" || s.Handler == nil {
+		return fmt.Errorf("subscriber needs a name and a handler")
+	}
+	if s.QueueSize <= 0 {
+		s.QueueSize = 1024
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	for _, sub := range b.subscriptions {
+		if sub.Name == s.Name {
+			return fmt.Errorf("subscriber %q is already registered", s.Name)
+		}
+	}
+
+	sub := &subscription{Subscriber: s}
+	if len(s.EventTypes) > 0 {
+		sub.types = make(map[string]bool, len(s.EventTypes))
+		for _, t := range s.EventTypes {
+			sub.types[t] = true
+		}
+	}
+	if s.Mode == Async {
+		sub.queue = make(chan queuedEvent, s.QueueSize)
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			for q := range sub.queue {
+				sub.deliver(q.ctx, q.event)
+			}
+		}()
+	}
+	b.subscriptions = append(b.subscriptions, sub)
+	return nil
+}
+
+// Publish delivers the event to every interested subscriber. Async
+// subscribers are only queued; sync subscribers then run in registration
+// order, without the bus lock held so that they may subscribe or publish
+// themselves, and their errors are returned together.
+func (b *EventBus) Publish(ctx context.Context, event *domain.Event) error {
+	var direct []*subscription
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	for _, sub := range b.subscriptions {
+		if sub.types != nil && !sub.types[event.Type] {
+			continue
+		}
+		if sub.Mode == Async {
+			// Queues are closed under the write lock, so they are only
+			// written to under the read lock
+			sub.enqueue(ctx, event)
+			continue
+		}
+		direct = append(direct, sub)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range direct {
+		if err := sub.deliver(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BestEffort returns a publisher that delivers events like Publish but never
+// fails: sync subscriber errors are only logged and counted in Stats. Use it
+// behind a broker, where a subscriber failure must not make the broker
+// publish count as failed.
+func (b *EventBus) BestEffort() external.EventPublisher {
+	return bestEffortPublisher{bus: b}
+}
+
+// Stats returns the counters of every subscriber in registration order
+func (b *EventBus) Stats() []external.SubscriberStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := make([]external.SubscriberStats, len(b.subscriptions))
+	for i, sub := range b.subscriptions {
+		lastError, _ := sub.lastError.Load().(string)
+		stats[i] = external.SubscriberStats{
+			Name:      sub.Name,
+			Mode:      sub.Mode.String(),
+			Delivered: sub.delivered.Load(),
+			Failed:    sub.failed.Load(),
+			Panics:    sub.panics.Load(),
+			Dropped:   sub.dropped.Load(),
+			LastError: lastError,
+		}
+	}
+	return stats
+}
+
+// Close stops accepting events and waits for async subscribers to handle
+// the events already queued
+func (b *EventBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	for _, sub := range b.subscriptions {
+		if sub.queue != nil {
+			close(sub.queue)
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	return nil
+}
+
+// bestEffortPublisher publishes to a bus, ignoring subscriber errors
+type bestEffortPublisher struct {
+	bus *EventBus
+}
+
+// Publish delivers the event to the bus subscribers. Their errors were
+// already logged and counted by the bus.
+func (p bestEffortPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	if err := p.bus.Publish(ctx, event); errors.Is(err, ErrClosed) {
+		log.Printf("[EventBus] bus is closed, %s event %s not delivered", event.Type, event.ID)
+	}
+	return nil
+}
+
+// Close closes the bus
+func (p bestEffortPublisher) Close() error {
+	return p.bus.Close()
+}
+
+// enqueue queues an event for an async subscriber without waiting. The
+// handler gets ctx's values but not its cancellation, since it usually runs
+// after Publish returned.
+func (s *subscription) enqueue(ctx context.Context, event *domain.Event) {
+	select {
+	case s.queue <- queuedEvent{ctx: context.WithoutCancel(ctx), event: event}:
+	default:
+		s.dropped.Add(1)
+		log.Printf("[EventBus] subscriber %s queue is full, dropped %s event %s", s.Name, event.Type, event.ID)
+	}
+}
+
+// deliver runs the handler, turning a panic into an error, and records the
+// outcome
+func (s *subscription) deliver(ctx context.Context, event *domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.panics.Add(1)
+			err = fmt.Errorf("subscriber %s panicked: %v", s.Name, r)
+			log.Printf("[EventBus] %v\n%s", err, debug.Stack())
+		}
+		if err != nil {
+			s.failed.Add(1)
+			s.lastError.Store(err.Error())
+			log.Printf("[EventBus] subscriber %s failed to handle %s event %s: %v", s.Name, event.Type, event.ID, err)
+			return
+		}
+		s.delivered.Add(1)
+	}()
+
+	if err := s.Handler(ctx, event); err != nil {
+		return fmt.Errorf("subscriber %s: %w", s.Name, err)
+	}
+	return nil
+}
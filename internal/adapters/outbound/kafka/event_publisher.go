@@ -1,27 +1,202 @@
 package kafka
 
 import (
+	"context"
+	"errors"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/config"
 	"example-service/internal/domain"
 	"example-service/internal/ports/external"
-	"log"
+	"example-service/pkg/cloudevents"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// closeTimeout bounds how long Close waits for buffered records
+const closeTimeout = 10 * time.Second
+
+// Kafka protocol binding headers
+const (
+	contentTypeHeader = "content-type"
+	attributePrefix   = "ce_"
 )
 
 // EventPublisher implements the event publisher interface using Kafka
 type EventPublisher struct {
-	// In a real implementation, this would contain a Kafka producer
-	// For now, it's a placeholder that logs events
+	client      *kgo.Client
+	encoder     *envelope.Encoder
+	structured  bool
+	topicPrefix string
+	topics      map[string]string
 }
 
-// NewEventPublisher creates a new Kafka event publisher
-func NewEventPublisher() external.EventPublisher {
-	return &EventPublisher{}
+// NewEventPublisher creates a Kafka event publisher. Brokers are contacted
+// lazily, so an unreachable cluster surfaces as Publish errors.
+func NewEventPublisher(cfg config.KafkaConfig, encoder *envelope.Encoder) (external.EventPublisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	switch cfg.ContentMode {
+	case cloudevents.ModeBinary, cloudevents.ModeStructured, "":
+	default:
+		return nil, fmt.Errorf("kafka: unknown content mode %q", cfg.ContentMode)
+	}
+
+	acks, err := parseAcks(cfg.Acks)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := parseCompression(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.RequiredAcks(acks),
+		kgo.ProducerBatchCompression(compression),
+	}
+	if cfg.ClientID != "" {
+		opts = append(opts, kgo.ClientID(cfg.ClientID))
+	}
+	if cfg.Idempotent {
+		if cfg.Acks != "all" {
+			return nil, errors.New("kafka: idempotent producer requires acks=all")
+		}
+	} else {
+		opts = append(opts, kgo.DisableIdempotentWrite())
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+
+	return &EventPublisher{
+		client:      client,
+		encoder:     encoder,
+		structured:  cfg.ContentMode == cloudevents.ModeStructured,
+		topicPrefix: cfg.TopicPrefix,
+		topics:      cfg.Topics,
+	}, nil
 }
 
-// Publish publishes a domain event
-func (p *EventPublisher) Publish(event *domain.Event) error {
-	// TODO: Implement actual Kafka publishing
-	// For now, just log the event
-	log.Printf("[EventPublisher] Publishing event: Type=%s, Timestamp=%v", event.Type, event.Timestamp)
+// Publish writes the event as a CloudEvent to the topic for its type and
+// waits for the broker to acknowledge it. Records are keyed by example id so
+// all events for one example land on the same partition, in order.
+func (p *EventPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	ce, err := p.encoder.Encode(event)
+	if err != nil {
+		return err
+	}
+
+	record := &kgo.Record{
+		Topic:     p.topicFor(event.Type),
+		Key:       []byte(strconv.FormatInt(event.AggregateID, 10)),
+		Timestamp: event.Timestamp,
+	}
+	if err := p.encodeRecord(record, ce); err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	if err := p.client.ProduceSync(ctx, record).FirstErr(); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
 	return nil
 }
 
+// Close flushes pending events and closes the producer
+func (p *EventPublisher) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+
+	err := p.client.Flush(ctx)
+	p.client.Close()
+	if err != nil {
+		return fmt.Errorf("failed to flush kafka producer: %w", err)
+	}
+	return nil
+}
+
+// encodeRecord sets the record value and headers for the configured content
+// mode, following the CloudEvents Kafka protocol binding
+func (p *EventPublisher) encodeRecord(record *kgo.Record, ce *cloudevents.Event) error {
+	if p.structured {
+		value, err := cloudevents.MarshalStructured(ce)
+		if err != nil {
+			return err
+		}
+		record.Value = value
+		record.Headers = []kgo.RecordHeader{{Key: contentTypeHeader, Value: []byte(cloudevents.StructuredContentType)}}
+		return nil
+	}
+
+	attrs, err := cloudevents.BinaryAttributes(ce)
+	if err != nil {
+		return err
+	}
+	record.Value = ce.Data
+	record.Headers = append(record.Headers, kgo.RecordHeader{Key: contentTypeHeader, Value: []byte(ce.DataContentType)})
+	for name, value := range attrs {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: attributePrefix + name, Value: []byte(value)})
+	}
+	return nil
+}
+
+// topicFor returns the topic configured for an event type
+func (p *EventPublisher) topicFor(eventType string) string {
+	if topic, ok := p.topics[eventType]; ok {
+		return topic
+	}
+	return p.topicPrefix + kebabCase(eventType)
+}
+
+// kebabCase converts "ExampleCreated" to "example-created"
+func kebabCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func parseAcks(acks string) (kgo.Acks, error) {
+	switch acks {
+	case "all":
+		return kgo.AllISRAcks(), nil
+	case "leader":
+		return kgo.LeaderAck(), nil
+	case "none":
+		return kgo.NoAck(), nil
+	default:
+		return kgo.Acks{}, fmt.Errorf("kafka: unknown acks %q", acks)
+	}
+}
+
+func parseCompression(codec string) (kgo.CompressionCodec, error) {
+	switch codec {
+	case "none", "":
+		return kgo.NoCompression(), nil
+	case "gzip":
+		return kgo.GzipCompression(), nil
+	case "snappy":
+		return kgo.SnappyCompression(), nil
+	case "lz4":
+		return kgo.Lz4Compression(), nil
+	case "zstd":
+		return kgo.ZstdCompression(), nil
+	default:
+		return kgo.CompressionCodec{}, fmt.Errorf("kafka: unknown compression %q", codec)
+	}
+}
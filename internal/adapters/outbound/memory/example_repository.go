@@ -0,0 +1,232 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ExampleRepository implements the example repository interface in memory
+type ExampleRepository struct {
+	store *Store
+}
+
+// NewExampleRepository creates a new in-memory example repository
+func NewExampleRepository(store *Store) *ExampleRepository {
+	return &ExampleRepository{
+		store: store,
+	}
+}
+
+var _ repositories.ExampleRepository = (*ExampleRepository)(nil)
+
+// Create creates a new example, returning domain.ErrExampleAlreadyExists if
+// the name is taken. Like the database defaults, an empty status becomes
+// active and the version starts at 1.
+func (r *ExampleRepository) Create(ctx context.Context, example *domain.Example) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.nameTaken(example.Name, 0) {
+		return domain.ErrExampleAlreadyExists
+	}
+	if example.ID == 0 {
+		example.ID = r.store.lastExampleID + 1
+	} else if _, ok := r.store.examples[example.ID]; ok {
+		return domain.ErrExampleAlreadyExists
+	}
+	r.store.lastExampleID = max(r.store.lastExampleID, example.ID)
+
+	if example.Status == "" {
+		example.Status = "active"
+	}
+	if example.Version == 0 {
+		example.Version = 1
+	}
+	example.CreatedAt = timeOrNow(example.CreatedAt)
+	example.UpdatedAt = timeOrNow(example.UpdatedAt)
+
+	stored := *example
+	r.store.examples[example.ID] = &stored
+	return nil
+}
+
+// FindByID finds an example by ID, returning nil if there is none
+func (r *ExampleRepository) FindByID(ctx context.Context, id int64) (*domain.Example, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stored, ok := r.store.examples[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *stored
+	return &found, nil
+}
+
+// List finds one page of examples matching the query, ordered and resumed
+// like the keyset pagination of the database adapter
+func (r *ExampleRepository) List(ctx context.Context, query repositories.ExampleQuery) ([]*domain.Example, error) {
+	compare, err := exampleComparer(query.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	if query.Desc {
+		asc := compare
+		compare = func(a, b *domain.Example) int { return asc(b, a) }
+	}
+
+	var cursor *domain.Example
+	if after := query.After; after != nil {
+		cursor = &domain.Example{ID: after.ID, Name: after.Name, CreatedAt: after.CreatedAt, UpdatedAt: after.UpdatedAt}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var examples []*domain.Example
+	for _, stored := range r.store.examples {
+		if !matchesFilter(stored, query.Filter) {
+			continue
+		}
+		if cursor != nil && compare(stored, cursor) <= 0 {
+			continue
+		}
+		found := *stored
+		examples = append(examples, &found)
+	}
+	slices.SortFunc(examples, compare)
+	if query.Limit > 0 && len(examples) > query.Limit {
+		examples = examples[:query.Limit]
+	}
+	return examples, nil
+}
+
+// Count counts the examples matching the filter
+func (r *ExampleRepository) Count(ctx context.Context, filter repositories.ExampleFilter) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, stored := range r.store.examples {
+		if matchesFilter(stored, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Update updates an existing example if it is still at example.Version,
+// returning domain.ErrVersionConflict otherwise and
+// domain.ErrExampleAlreadyExists if a rename collides. On success the
+// version is incremented.
+func (r *ExampleRepository) Update(ctx context.Context, example *domain.Example) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.examples[example.ID]
+	if !ok || stored.Version != example.Version {
+		return domain.ErrVersionConflict
+	}
+	if r.nameTaken(example.Name, example.ID) {
+		return domain.ErrExampleAlreadyExists
+	}
+
+	stored.Name = example.Name
+	stored.Status = example.Status
+	stored.UpdatedAt = example.UpdatedAt
+	stored.Version++
+	example.Version++
+	return nil
+}
+
+// Delete deletes an example by ID; deleting a missing example is not an error
+func (r *ExampleRepository) Delete(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.examples, id)
+	return nil
+}
+
+// Exists checks if an example exists with the given name
+func (r *ExampleRepository) Exists(ctx context.Context, name string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, stored := range r.store.examples {
+		if stored.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// nameTaken reports whether an example other than exceptID uses name, the
+// way the unique name index compares names. Callers hold the store lock.
+func (r *ExampleRepository) nameTaken(name string, exceptID int64) bool {
+	key := r.nameKey(name)
+	for _, stored := range r.store.examples {
+		if stored.ID != exceptID && r.nameKey(stored.Name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// nameKey returns the value the unique name index holds for name
+func (r *ExampleRepository) nameKey(name string) string {
+	if r.store.opts.CaseInsensitiveNames {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// matchesFilter reports whether an example passes the filter
+func matchesFilter(example *domain.Example, filter repositories.ExampleFilter) bool {
+	if filter.Status != "" && example.Status != filter.Status {
+		return false
+	}
+	if filter.AccountID != "" && example.AccountID != filter.AccountID {
+		return false
+	}
+	if filter.NamePrefix != "" && !strings.HasPrefix(example.Name, filter.NamePrefix) {
+		return false
+	}
+	if !filter.CreatedAfter.IsZero() && example.CreatedAt.Before(filter.CreatedAfter) {
+		return false
+	}
+	if !filter.CreatedBefore.IsZero() && !example.CreatedAt.Before(filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// exampleComparer returns the ascending order of a sort field, with the ID
+// breaking ties, rejecting unknown fields
+func exampleComparer(field repositories.ExampleSortField) (func(a, b *domain.Example) int, error) {
+	var key func(a, b *domain.Example) int
+	switch field {
+	case "", repositories.SortByID:
+		key = func(a, b *domain.Example) int { return 0 }
+	case repositories.SortByName:
+		key = func(a, b *domain.Example) int { return strings.Compare(a.Name, b.Name) }
+	case repositories.SortByCreatedAt:
+		key = func(a, b *domain.Example) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case repositories.SortByUpdatedAt:
+		key = func(a, b *domain.Example) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+	default:
+		return nil, fmt.Errorf("unsupported sort field %q", field)
+	}
+
+	return func(a, b *domain.Example) int {
+		if c := key(a, b); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	}, nil
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"example-service/internal/domain"
+	"slices"
+	"time"
+)
+
+// OutboxRepository implements the outbox repository interface in memory
+type OutboxRepository struct {
+	store *Store
+}
+
+// NewOutboxRepository creates a new in-memory outbox repository
+func NewOutboxRepository(store *Store) *OutboxRepository {
+	return &OutboxRepository{
+		store: store,
+	}
+}
+
+// Add stores a message
+func (r *OutboxRepository) Add(ctx context.Context, message *domain.OutboxMessage) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.lastOutboxID++
+	message.ID = r.store.lastOutboxID
+	message.CreatedAt = timeOrNow(message.CreatedAt)
+	stored := *message
+	r.store.outbox = append(r.store.outbox, &stored)
+	return nil
+}
+
+// ClaimPending returns up to limit due messages, oldest first, that are not
+// queued behind an earlier unsent message for the same aggregate, moving
+// their next attempt to the end of the lease
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current := now()
+	blocked := make(map[int64]bool)
+	var messages []*domain.OutboxMessage
+	for _, stored := range r.store.outbox {
+		if len(messages) == limit {
+			break
+		}
+		if stored.SentAt != nil {
+			continue
+		}
+		if !blocked[stored.AggregateID] && stored.DeadLetteredAt == nil && !stored.NextAttemptAt.After(current) {
+			messages = append(messages, copyMessage(stored))
+			stored.NextAttemptAt = current.Add(lease)
+		}
+		blocked[stored.AggregateID] = true
+	}
+	return messages, nil
+}
+
+// MarkSent records that a message was published
+func (r *OutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	r.update(id, func(m *domain.OutboxMessage) {
+		sentAt := now()
+		m.SentAt = &sentAt
+	})
+	return nil
+}
+
+// MarkFailed records a failed publish attempt and when to retry
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, lastErr string, nextAttemptAt time.Time) error {
+	r.update(id, func(m *domain.OutboxMessage) {
+		m.Attempts++
+		m.LastError = lastErr
+		m.NextAttemptAt = nextAttemptAt
+	})
+	return nil
+}
+
+// MarkDeadLettered records a final failed publish attempt and parks the message
+func (r *OutboxRepository) MarkDeadLettered(ctx context.Context, id int64, lastErr string) error {
+	r.update(id, func(m *domain.OutboxMessage) {
+		deadLetteredAt := now()
+		m.Attempts++
+		m.LastError = lastErr
+		m.DeadLetteredAt = &deadLetteredAt
+	})
+	return nil
+}
+
+// ListDeadLettered returns up to limit parked messages, most recently parked first
+func (r *OutboxRepository) ListDeadLettered(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var messages []*domain.OutboxMessage
+	for _, stored := range r.store.outbox {
+		if stored.DeadLetteredAt != nil {
+			messages = append(messages, copyMessage(stored))
+		}
+	}
+	slices.SortFunc(messages, func(a, b *domain.OutboxMessage) int {
+		if c := b.DeadLetteredAt.Compare(*a.DeadLetteredAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+// CountDeadLettered returns the number of parked messages
+func (r *OutboxRepository) CountDeadLettered(ctx context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, stored := range r.store.outbox {
+		if stored.DeadLetteredAt != nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Requeue makes a parked message due again, reporting false if no parked
+// message has the id
+func (r *OutboxRepository) Requeue(ctx context.Context, id int64) (bool, error) {
+	requeued := false
+	r.update(id, func(m *domain.OutboxMessage) {
+		if m.DeadLetteredAt == nil {
+			return
+		}
+		m.Attempts = 0
+		m.DeadLetteredAt = nil
+		m.NextAttemptAt = now()
+		requeued = true
+	})
+	return requeued, nil
+}
+
+// ListAfter returns up to limit messages with an id greater than afterID, in id order
+func (r *OutboxRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]*domain.OutboxMessage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var messages []*domain.OutboxMessage
+	for _, stored := range r.store.outbox {
+		if len(messages) == limit {
+			break
+		}
+		if stored.ID > afterID {
+			messages = append(messages, copyMessage(stored))
+		}
+	}
+	return messages, nil
+}
+
+// ListByIDs returns the messages with the given ids that exist, in id order
+func (r *OutboxRepository) ListByIDs(ctx context.Context, ids []int64) ([]*domain.OutboxMessage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var messages []*domain.OutboxMessage
+	for _, stored := range r.store.outbox {
+		if slices.Contains(ids, stored.ID) {
+			messages = append(messages, copyMessage(stored))
+		}
+	}
+	return messages, nil
+}
+
+// LastID returns the id of the newest message, or 0 when the outbox is empty
+func (r *OutboxRepository) LastID(ctx context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if len(r.store.outbox) == 0 {
+		return 0, nil
+	}
+	return r.store.outbox[len(r.store.outbox)-1].ID, nil
+}
+
+// update applies fn to the stored message with the given id, if any
+func (r *OutboxRepository) update(id int64, fn func(m *domain.OutboxMessage)) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	i, found := slices.BinarySearchFunc(r.store.outbox, id, func(m *domain.OutboxMessage, id int64) int {
+		return cmp.Compare(m.ID, id)
+	})
+	if found {
+		fn(r.store.outbox[i])
+	}
+}
+
+// copyMessage returns a copy of a stored message that callers may modify
+func copyMessage(m *domain.OutboxMessage) *domain.OutboxMessage {
+	c := *m
+	return &c
+}
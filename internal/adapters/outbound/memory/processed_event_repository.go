@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"example-service/internal/domain"
+)
+
+// ProcessedEventRepository implements the processed event repository interface in memory
+type ProcessedEventRepository struct {
+	store *Store
+}
+
+// NewProcessedEventRepository creates a new in-memory processed event repository
+func NewProcessedEventRepository(store *Store) *ProcessedEventRepository {
+	return &ProcessedEventRepository{
+		store: store,
+	}
+}
+
+// IsProcessed reports whether consumer has already handled the event
+func (r *ProcessedEventRepository) IsProcessed(ctx context.Context, consumer, eventID string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.processed[processedKey{consumer: consumer, eventID: eventID}]
+	return ok, nil
+}
+
+// MarkProcessed records a handled event, returning domain.ErrEventProcessed
+// if it is already recorded
+func (r *ProcessedEventRepository) MarkProcessed(ctx context.Context, event *domain.ProcessedEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := processedKey{consumer: event.Consumer, eventID: event.EventID}
+	if _, ok := r.store.processed[key]; ok {
+		return domain.ErrEventProcessed
+	}
+	event.ProcessedAt = timeOrNow(event.ProcessedAt)
+	stored := *event
+	r.store.processed[key] = &stored
+	return nil
+}
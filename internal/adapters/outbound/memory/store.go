@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"example-service/internal/domain"
+	"sync"
+	"time"
+)
+
+// Options configures an in-memory store
+type Options struct {
+	// CaseInsensitiveNames makes example names unique regardless of case
+	CaseInsensitiveNames bool
+}
+
+// Store holds the data of the in-memory repositories, the counterpart of a
+// database. Repositories created from the same store see each other's
+// writes. Every operation takes the store lock, so repositories are safe for
+// concurrent use, but there are no transactions: writes are visible as soon
+// as they are made.
+type Store struct {
+	mu   sync.RWMutex
+	opts Options
+
+	examples      map[int64]*domain.Example
+	outbox        []*domain.OutboxMessage
+	processed     map[processedKey]*domain.ProcessedEvent
+	subscriptions map[int64]*domain.WebhookSubscription
+	deliveries    []*domain.WebhookDelivery
+
+	lastExampleID      int64
+	lastOutboxID       int64
+	lastSubscriptionID int64
+	lastDeliveryID     int64
+}
+
+// processedKey is the primary key of a processed event
+type processedKey struct {
+	consumer string
+	eventID  string
+}
+
+// NewStore creates an empty in-memory store
+func NewStore(opts Options) *Store {
+	return &Store{
+		opts:          opts,
+		examples:      make(map[int64]*domain.Example),
+		processed:     make(map[processedKey]*domain.ProcessedEvent),
+		subscriptions: make(map[int64]*domain.WebhookSubscription),
+	}
+}
+
+// now returns the current time in UTC, like the GORM adapters store it
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// timeOrNow returns t, or the current time if t is zero
+func timeOrNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return now()
+	}
+	return t
+}
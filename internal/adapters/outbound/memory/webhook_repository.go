@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"example-service/internal/domain"
+	"slices"
+	"time"
+)
+
+// WebhookSubscriptionRepository implements the webhook subscription repository interface in memory
+type WebhookSubscriptionRepository struct {
+	store *Store
+}
+
+// NewWebhookSubscriptionRepository creates a new in-memory webhook subscription repository
+func NewWebhookSubscriptionRepository(store *Store) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		store: store,
+	}
+}
+
+// Create stores a new subscription
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.lastSubscriptionID++
+	subscription.ID = r.store.lastSubscriptionID
+	subscription.CreatedAt = timeOrNow(subscription.CreatedAt)
+	subscription.UpdatedAt = timeOrNow(subscription.UpdatedAt)
+	r.store.subscriptions[subscription.ID] = copySubscription(subscription)
+	return nil
+}
+
+// FindByID finds a subscription by ID, returning nil if there is none
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	stored, ok := r.store.subscriptions[id]
+	if !ok {
+		return nil, nil
+	}
+	return copySubscription(stored), nil
+}
+
+// List returns every subscription, oldest first
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return r.list(false), nil
+}
+
+// ListActive returns the subscriptions that receive deliveries
+func (r *WebhookSubscriptionRepository) ListActive(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return r.list(true), nil
+}
+
+// RecordSuccess resets the consecutive failures of a subscription
+func (r *WebhookSubscriptionRepository) RecordSuccess(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if stored, ok := r.store.subscriptions[id]; ok {
+		stored.ConsecutiveFailures = 0
+		stored.UpdatedAt = now()
+	}
+	return nil
+}
+
+// RecordFailure increments the consecutive failures of a subscription and
+// disables it once they reach disableAfter
+func (r *WebhookSubscriptionRepository) RecordFailure(ctx context.Context, id int64, disableAfter int) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.subscriptions[id]
+	if !ok {
+		return false, nil
+	}
+	current := now()
+	stored.ConsecutiveFailures++
+	stored.UpdatedAt = current
+	if !stored.Active || stored.ConsecutiveFailures < disableAfter {
+		return false, nil
+	}
+	stored.Active = false
+	stored.DisabledAt = &current
+	return true, nil
+}
+
+// Delete removes a subscription and its deliveries
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id int64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deliveries = slices.DeleteFunc(r.store.deliveries, func(d *domain.WebhookDelivery) bool {
+		return d.SubscriptionID == id
+	})
+	delete(r.store.subscriptions, id)
+	return nil
+}
+
+// list returns the subscriptions in id order, only the active ones if
+// activeOnly is set
+func (r *WebhookSubscriptionRepository) list(activeOnly bool) []*domain.WebhookSubscription {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var subscriptions []*domain.WebhookSubscription
+	for _, stored := range r.store.subscriptions {
+		if !activeOnly || stored.Active {
+			subscriptions = append(subscriptions, copySubscription(stored))
+		}
+	}
+	slices.SortFunc(subscriptions, func(a, b *domain.WebhookSubscription) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return subscriptions
+}
+
+// WebhookDeliveryRepository implements the webhook delivery repository interface in memory
+type WebhookDeliveryRepository struct {
+	store *Store
+}
+
+// NewWebhookDeliveryRepository creates a new in-memory webhook delivery repository
+func NewWebhookDeliveryRepository(store *Store) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		store: store,
+	}
+}
+
+// Enqueue stores a pending delivery
+func (r *WebhookDeliveryRepository) Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.lastDeliveryID++
+	delivery.ID = r.store.lastDeliveryID
+	delivery.CreatedAt = timeOrNow(delivery.CreatedAt)
+	stored := *delivery
+	r.store.deliveries = append(r.store.deliveries, &stored)
+	return nil
+}
+
+// ClaimDue returns up to limit due deliveries that are at the head of an
+// active subscription's queue, oldest first, moving their next attempt to
+// the end of the lease
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current := now()
+	queued := make(map[int64]bool)
+	var deliveries []*domain.WebhookDelivery
+	for _, stored := range r.store.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		if stored.Status != domain.DeliveryPending {
+			continue
+		}
+		subscription, ok := r.store.subscriptions[stored.SubscriptionID]
+		if ok && subscription.Active && !queued[stored.SubscriptionID] && !stored.NextAttemptAt.After(current) {
+			found := *stored
+			deliveries = append(deliveries, &found)
+			stored.NextAttemptAt = current.Add(lease)
+		}
+		queued[stored.SubscriptionID] = true
+	}
+	return deliveries, nil
+}
+
+// MarkSucceeded records a successful attempt
+func (r *WebhookDeliveryRepository) MarkSucceeded(ctx context.Context, id int64, responseStatus int) error {
+	r.recordAttempt(id, responseStatus, "", func(d *domain.WebhookDelivery) {
+		deliveredAt := now()
+		d.Status = domain.DeliverySucceeded
+		d.DeliveredAt = &deliveredAt
+	})
+	return nil
+}
+
+// MarkRetrying records a failed attempt and when to retry
+func (r *WebhookDeliveryRepository) MarkRetrying(ctx context.Context, id int64, responseStatus int, lastErr string, nextAttemptAt time.Time) error {
+	r.recordAttempt(id, responseStatus, lastErr, func(d *domain.WebhookDelivery) {
+		d.NextAttemptAt = nextAttemptAt
+	})
+	return nil
+}
+
+// MarkFailed records a final failed attempt
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id int64, responseStatus int, lastErr string) error {
+	r.recordAttempt(id, responseStatus, lastErr, func(d *domain.WebhookDelivery) {
+		d.Status = domain.DeliveryFailed
+	})
+	return nil
+}
+
+// recordAttempt counts an attempt at the stored delivery with the given id,
+// if any, and applies its outcome
+func (r *WebhookDeliveryRepository) recordAttempt(id int64, responseStatus int, lastErr string, fn func(d *domain.WebhookDelivery)) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, stored := range r.store.deliveries {
+		if stored.ID == id {
+			stored.Attempts++
+			stored.ResponseStatus = responseStatus
+			stored.LastError = lastErr
+			fn(stored)
+			return
+		}
+	}
+}
+
+// ListBySubscription returns the most recent deliveries of a subscription, newest first
+func (r *WebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var deliveries []*domain.WebhookDelivery
+	for i := len(r.store.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if stored := r.store.deliveries[i]; stored.SubscriptionID == subscriptionID {
+			found := *stored
+			deliveries = append(deliveries, &found)
+		}
+	}
+	return deliveries, nil
+}
+
+// copySubscription returns a copy of a subscription that does not share its
+// event types
+func copySubscription(s *domain.WebhookSubscription) *domain.WebhookSubscription {
+	c := *s
+	c.EventTypes = slices.Clone(s.EventTypes)
+	return &c
+}
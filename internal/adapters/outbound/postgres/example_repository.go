@@ -1,7 +1,13 @@
 package postgres
 
 import (
+	"context"
+	"errors"
 	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"strings"
+
 	"gorm.io/gorm"
 )
 
@@ -17,15 +23,16 @@ func NewExampleRepository(db *gorm.DB) *ExampleRepository {
 	}
 }
 
-// Create creates a new example
-func (r *ExampleRepository) Create(example *domain.Example) error {
-	return r.db.Create(example).Error
+// Create creates a new example, returning domain.ErrExampleAlreadyExists if
+// the name is taken
+func (r *ExampleRepository) Create(ctx context.Context, example *domain.Example) error {
+	return translateError(conn(ctx, r.db).Create(example).Error)
 }
 
 // FindByID finds an example by ID
-func (r *ExampleRepository) FindByID(id int64) (*domain.Example, error) {
+func (r *ExampleRepository) FindByID(ctx context.Context, id int64) (*domain.Example, error) {
 	var example domain.Example
-	if err := r.db.First(&example, id).Error; err != nil {
+	if err := conn(ctx, r.db).First(&example, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -34,31 +41,140 @@ func (r *ExampleRepository) FindByID(id int64) (*domain.Example, error) {
 	return &example, nil
 }
 
-// FindAll finds all examples
-func (r *ExampleRepository) FindAll() ([]*domain.Example, error) {
+// List finds one page of examples matching the query using keyset pagination
+func (r *ExampleRepository) List(ctx context.Context, query repositories.ExampleQuery) ([]*domain.Example, error) {
+	column, err := sortColumn(query.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	direction, op := "ASC", ">"
+	if query.Desc {
+		direction, op = "DESC", "<"
+	}
+
+	db := applyExampleFilter(conn(ctx, r.db).Model(&domain.Example{}), query.Filter)
+	if after := query.After; after != nil {
+		if column == "id" {
+			db = db.Where("id "+op+" ?", after.ID)
+		} else {
+			db = db.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, op), cursorValue(column, after), after.ID)
+		}
+	}
+	if column != "id" {
+		db = db.Order(column + " " + direction)
+	}
+	db = db.Order("id " + direction)
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
 	var examples []*domain.Example
-	if err := r.db.Find(&examples).Error; err != nil {
+	if err := db.Find(&examples).Error; err != nil {
 		return nil, err
 	}
 	return examples, nil
 }
 
-// Update updates an existing example
-func (r *ExampleRepository) Update(example *domain.Example) error {
-	return r.db.Save(example).Error
+// Count counts the examples matching the filter
+func (r *ExampleRepository) Count(ctx context.Context, filter repositories.ExampleFilter) (int64, error) {
+	var count int64
+	db := applyExampleFilter(conn(ctx, r.db).Model(&domain.Example{}), filter)
+	if err := db.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Update updates an existing example if it is still at example.Version,
+// returning domain.ErrVersionConflict otherwise and
+// domain.ErrExampleAlreadyExists if a rename collides. On success the
+// version is incremented.
+func (r *ExampleRepository) Update(ctx context.Context, example *domain.Example) error {
+	result := conn(ctx, r.db).Model(&domain.Example{}).
+		Where("id = ? AND version = ?", example.ID, example.Version).
+		Updates(map[string]interface{}{
+			"name":       example.Name,
+			"status":     example.Status,
+			"updated_at": example.UpdatedAt,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrVersionConflict
+	}
+
+	example.Version++
+	return nil
 }
 
 // Delete deletes an example by ID
-func (r *ExampleRepository) Delete(id int64) error {
-	return r.db.Delete(&domain.Example{}, id).Error
+func (r *ExampleRepository) Delete(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Delete(&domain.Example{}, id).Error
 }
 
 // Exists checks if an example exists with the given name
-func (r *ExampleRepository) Exists(name string) (bool, error) {
+func (r *ExampleRepository) Exists(ctx context.Context, name string) (bool, error) {
 	var count int64
-	if err := r.db.Model(&domain.Example{}).Where("name = ?", name).Count(&count).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&domain.Example{}).Where("name = ?", name).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
+// translateError maps unique violations on the name index to the domain error
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrExampleAlreadyExists
+	}
+	return err
+}
+
+// applyExampleFilter adds the filter conditions to a query
+func applyExampleFilter(db *gorm.DB, filter repositories.ExampleFilter) *gorm.DB {
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+	if filter.AccountID != "" {
+		db = db.Where("account_id = ?", filter.AccountID)
+	}
+	if filter.NamePrefix != "" {
+		db = db.Where(`name LIKE ? ESCAPE '\'`, likeEscaper.Replace(filter.NamePrefix)+"%")
+	}
+	if !filter.CreatedAfter.IsZero() {
+		db = db.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		db = db.Where("created_at < ?", filter.CreatedBefore)
+	}
+	return db
+}
+
+// likeEscaper escapes LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// sortColumn maps a sort field to its column, rejecting unknown fields
+func sortColumn(field repositories.ExampleSortField) (string, error) {
+	switch field {
+	case "", repositories.SortByID:
+		return "id", nil
+	case repositories.SortByName, repositories.SortByCreatedAt, repositories.SortByUpdatedAt:
+		return string(field), nil
+	default:
+		return "", fmt.Errorf("unsupported sort field %q", field)
+	}
+}
+
+// cursorValue returns the cursor's value for the sort column
+func cursorValue(column string, cursor *repositories.ExampleCursor) interface{} {
+	switch column {
+	case "name":
+		return cursor.Name
+	case "created_at":
+		return cursor.CreatedAt
+	default:
+		return cursor.UpdatedAt
+	}
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"example-service/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository implements the outbox repository interface using PostgreSQL
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new PostgreSQL outbox repository
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// Add stores a message, inside the transaction carried by ctx if any
+func (r *OutboxRepository) Add(ctx context.Context, message *domain.OutboxMessage) error {
+	return conn(ctx, r.db).Create(message).Error
+}
+
+// ClaimPending returns up to limit due messages, oldest first, that are not
+// queued behind an earlier unsent message for the same aggregate, parked or
+// not. The rows
+// are locked while they are claimed, skipping rows another relay is
+// claiming, and their next attempt is moved to the end of the lease.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("sent_at IS NULL AND dead_lettered_at IS NULL AND next_attempt_at <= ?", now).
+			Where(`NOT EXISTS (
+				SELECT 1 FROM outbox earlier
+				WHERE earlier.aggregate_id = outbox.aggregate_id
+				AND earlier.sent_at IS NULL
+				AND earlier.id < outbox.id)`).
+			Order("id").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(messages))
+		for i, msg := range messages {
+			ids[i] = msg.ID
+		}
+		return tx.Model(&domain.OutboxMessage{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// MarkSent records that a message was published
+func (r *OutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&domain.OutboxMessage{}).
+		Where("id = ?", id).
+		Update("sent_at", time.Now().UTC()).Error
+}
+
+// MarkFailed records a failed publish attempt and when to retry
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, lastErr string, nextAttemptAt time.Time) error {
+	return conn(ctx, r.db).Model(&domain.OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastErr,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+}
+
+// MarkDeadLettered records a final failed publish attempt and parks the message
+func (r *OutboxRepository) MarkDeadLettered(ctx context.Context, id int64, lastErr string) error {
+	return conn(ctx, r.db).Model(&domain.OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":         gorm.Expr("attempts + 1"),
+			"last_error":       lastErr,
+			"dead_lettered_at": time.Now().UTC(),
+		}).Error
+}
+
+// ListDeadLettered returns up to limit parked messages, most recently parked first
+func (r *OutboxRepository) ListDeadLettered(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	err := conn(ctx, r.db).
+		Where("dead_lettered_at IS NOT NULL").
+		Order("dead_lettered_at DESC, id DESC").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// CountDeadLettered returns the number of parked messages
+func (r *OutboxRepository) CountDeadLettered(ctx context.Context) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&domain.OutboxMessage{}).
+		Where("dead_lettered_at IS NOT NULL").
+		Count(&count).Error
+	return count, err
+}
+
+// Requeue makes a parked message due again, reporting false if no parked
+// message has the id
+func (r *OutboxRepository) Requeue(ctx context.Context, id int64) (bool, error) {
+	result := conn(ctx, r.db).Model(&domain.OutboxMessage{}).
+		Where("id = ? AND dead_lettered_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"attempts":         0,
+			"dead_lettered_at": nil,
+			"next_attempt_at":  time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListAfter returns up to limit messages with an id greater than afterID, in id order
+func (r *OutboxRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	err := conn(ctx, r.db).
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ListByIDs returns the messages with the given ids that exist, in id order
+func (r *OutboxRepository) ListByIDs(ctx context.Context, ids []int64) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	if len(ids) == 0 {
+		return messages, nil
+	}
+	err := conn(ctx, r.db).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// LastID returns the id of the newest message, or 0 when the outbox is empty
+func (r *OutboxRepository) LastID(ctx context.Context) (int64, error) {
+	var id int64
+	err := conn(ctx, r.db).Model(&domain.OutboxMessage{}).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error
+	return id, err
+}
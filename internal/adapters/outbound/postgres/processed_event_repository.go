@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ProcessedEventRepository implements the processed event repository interface using PostgreSQL
+type ProcessedEventRepository struct {
+	db *gorm.DB
+}
+
+// NewProcessedEventRepository creates a new PostgreSQL processed event repository
+func NewProcessedEventRepository(db *gorm.DB) *ProcessedEventRepository {
+	return &ProcessedEventRepository{
+		db: db,
+	}
+}
+
+// IsProcessed reports whether consumer has already handled the event
+func (r *ProcessedEventRepository) IsProcessed(ctx context.Context, consumer, eventID string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&domain.ProcessedEvent{}).
+		Where("consumer = ? AND event_id = ?", consumer, eventID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MarkProcessed records a handled event, inside the transaction carried by ctx if any
+func (r *ProcessedEventRepository) MarkProcessed(ctx context.Context, event *domain.ProcessedEvent) error {
+	if err := conn(ctx, r.db).Create(event).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrEventProcessed
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transactor implements the transactor interface using GORM transactions
+type Transactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor creates a new PostgreSQL transactor
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn inside a transaction carried by the context. If
+// ctx already carries a transaction, fn runs inside a savepoint of it. The
+// transaction or savepoint is rolled back when fn returns an error or
+// panics, and the panic is then raised again.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// GORM opens a savepoint when Transaction is called on a transaction
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db bound to ctx
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
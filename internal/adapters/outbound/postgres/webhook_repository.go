@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"example-service/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookSubscriptionRepository implements the webhook subscription repository interface using PostgreSQL
+type WebhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookSubscriptionRepository creates a new PostgreSQL webhook subscription repository
+func NewWebhookSubscriptionRepository(db *gorm.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		db: db,
+	}
+}
+
+// Create stores a new subscription
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return conn(ctx, r.db).Create(subscription).Error
+}
+
+// FindByID finds a subscription by ID
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	var subscription domain.WebhookSubscription
+	if err := conn(ctx, r.db).First(&subscription, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// List returns every subscription, oldest first
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	var subscriptions []*domain.WebhookSubscription
+	if err := conn(ctx, r.db).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// ListActive returns the subscriptions that receive deliveries
+func (r *WebhookSubscriptionRepository) ListActive(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	var subscriptions []*domain.WebhookSubscription
+	if err := conn(ctx, r.db).Where("active").Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// RecordSuccess resets the consecutive failures of a subscription
+func (r *WebhookSubscriptionRepository) RecordSuccess(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Model(&domain.WebhookSubscription{}).
+		Where("id = ?", id).
+		Update("consecutive_failures", 0).Error
+}
+
+// RecordFailure increments the consecutive failures of a subscription and
+// disables it once they reach disableAfter, in one transaction
+func (r *WebhookSubscriptionRepository) RecordFailure(ctx context.Context, id int64, disableAfter int) (bool, error) {
+	disabled := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.WebhookSubscription{}).
+			Where("id = ?", id).
+			Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&domain.WebhookSubscription{}).
+			Where("id = ? AND active AND consecutive_failures >= ?", id, disableAfter).
+			Updates(map[string]interface{}{
+				"active":      false,
+				"disabled_at": time.Now().UTC(),
+			})
+		disabled = result.RowsAffected > 0
+		return result.Error
+	})
+	return disabled, err
+}
+
+// Delete removes a subscription and its deliveries
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id int64) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.WebhookSubscription{}, id).Error
+	})
+}
+
+// WebhookDeliveryRepository implements the webhook delivery repository interface using PostgreSQL
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates a new PostgreSQL webhook delivery repository
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db: db,
+	}
+}
+
+// Enqueue stores a pending delivery, inside the transaction carried by ctx if any
+func (r *WebhookDeliveryRepository) Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return conn(ctx, r.db).Create(delivery).Error
+}
+
+// ClaimDue returns up to limit due deliveries that are at the head of an
+// active subscription's queue, oldest first. The rows are locked while they
+// are claimed, skipping rows another dispatcher is claiming, and their next
+// attempt is moved to the end of the lease.
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+			Where("subscription_id IN (SELECT id FROM webhook_subscriptions WHERE active)").
+			Where(`NOT EXISTS (
+				SELECT 1 FROM webhook_deliveries earlier
+				WHERE earlier.subscription_id = webhook_deliveries.subscription_id
+				AND earlier.status = ?
+				AND earlier.id < webhook_deliveries.id)`, domain.DeliveryPending).
+			Order("id").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&domain.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// MarkSucceeded records a successful attempt
+func (r *WebhookDeliveryRepository) MarkSucceeded(ctx context.Context, id int64, responseStatus int) error {
+	return r.recordAttempt(ctx, id, map[string]interface{}{
+		"status":          domain.DeliverySucceeded,
+		"response_status": responseStatus,
+		"last_error":      "",
+		"delivered_at":    time.Now().UTC(),
+	})
+}
+
+// MarkRetrying records a failed attempt and when to retry
+func (r *WebhookDeliveryRepository) MarkRetrying(ctx context.Context, id int64, responseStatus int, lastErr string, nextAttemptAt time.Time) error {
+	return r.recordAttempt(ctx, id, map[string]interface{}{
+		"response_status": responseStatus,
+		"last_error":      lastErr,
+		"next_attempt_at": nextAttemptAt,
+	})
+}
+
+// MarkFailed records a final failed attempt
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id int64, responseStatus int, lastErr string) error {
+	return r.recordAttempt(ctx, id, map[string]interface{}{
+		"status":          domain.DeliveryFailed,
+		"response_status": responseStatus,
+		"last_error":      lastErr,
+	})
+}
+
+// recordAttempt counts an attempt at a delivery and applies its outcome
+func (r *WebhookDeliveryRepository) recordAttempt(ctx context.Context, id int64, outcome map[string]interface{}) error {
+	outcome["attempts"] = gorm.Expr("attempts + 1")
+	return conn(ctx, r.db).Model(&domain.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(outcome).Error
+}
+
+// ListBySubscription returns the most recent deliveries of a subscription, newest first
+func (r *WebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	err := conn(ctx, r.db).
+		Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
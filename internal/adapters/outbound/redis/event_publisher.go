@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/config"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"example-service/pkg/cloudevents"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Stream entry fields. Attributes are stored like the CloudEvents binary
+// content mode, one ce_ field per attribute next to the data.
+const (
+	contentTypeField = "content-type"
+	dataField        = "data"
+	attributePrefix  = "ce_"
+)
+
+// EventPublisher implements the event publisher interface using Redis Streams
+type EventPublisher struct {
+	client  *redis.Client
+	encoder *envelope.Encoder
+	stream  string
+	maxLen  int64
+}
+
+// NewEventPublisher creates a Redis Streams event publisher. The client is
+// owned by the caller.
+func NewEventPublisher(client *redis.Client, cfg config.RedisStreamConfig, encoder *envelope.Encoder) external.EventPublisher {
+	return &EventPublisher{
+		client:  client,
+		encoder: encoder,
+		stream:  cfg.Stream,
+		maxLen:  cfg.MaxLen,
+	}
+}
+
+// Publish appends the event to the stream, trimming it to roughly the
+// configured length
+func (p *EventPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	ce, err := p.encoder.Encode(event)
+	if err != nil {
+		return err
+	}
+	attrs, err := cloudevents.BinaryAttributes(ce)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	values := map[string]interface{}{
+		contentTypeField: ce.DataContentType,
+		dataField:        ce.Data,
+	}
+	for name, value := range attrs {
+		values[attributePrefix+name] = value
+	}
+
+	err = p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: true,
+		Values: values,
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+// Close releases resources; the Redis client is closed by its owner
+func (p *EventPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"example-service/pkg/cloudevents"
+	signature "example-service/pkg/webhook"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxResponseBody bounds how much of a response is read before it is discarded
+const maxResponseBody = 64 << 10
+
+// Sender implements the webhook sender interface over HTTP
+type Sender struct {
+	client  *http.Client
+	encoder *envelope.Encoder
+}
+
+// NewSender creates a webhook sender. Deliveries are posted as structured
+// CloudEvents built by the encoder, and each request is bounded by timeout.
+func NewSender(encoder *envelope.Encoder, timeout time.Duration) external.WebhookSender {
+	return &Sender{
+		client:  &http.Client{Timeout: timeout},
+		encoder: encoder,
+	}
+}
+
+// Send posts the delivery to the subscription's URL, signed with its secret
+func (s *Sender) Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	ce, err := s.encoder.Encode(&domain.Event{
+		ID:          delivery.EventID,
+		Type:        delivery.EventType,
+		Version:     delivery.EventVersion,
+		AggregateID: delivery.AggregateID,
+		Payload:     json.RawMessage(delivery.Payload),
+		Timestamp:   delivery.OccurredAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+	body, err := cloudevents.MarshalStructured(ce)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s event: %w", delivery.EventType, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", cloudevents.StructuredContentType)
+	req.Header.Set(signature.IDHeader, delivery.EventID)
+	req.Header.Set(signature.EventHeader, delivery.EventType)
+	req.Header.Set(signature.SignatureHeader, signature.Sign(subscription.Secret, time.Now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,465 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"example-service/internal/ports/services"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ChangeFeedConfig configures the change feed
+type ChangeFeedConfig struct {
+	// PollInterval is how often the outbox is checked for new events
+	PollInterval time.Duration
+	// BatchSize is the maximum number of events read at once
+	BatchSize int
+	// GapTimeout is how long the feed waits for a missing outbox id, which
+	// belongs to a transaction that has not committed yet, before moving
+	// past it
+	GapTimeout time.Duration
+	// GapRetention is how long the feed keeps looking up an id it moved
+	// past, so that a transaction committing after GapTimeout is still
+	// sent to the watchers
+	GapRetention time.Duration
+	// Buffer is the number of changes queued per watcher before it has to
+	// catch up from the outbox
+	Buffer int
+}
+
+// ChangeFeed streams example changes to watchers. It tails the outbox, so
+// it sees the events ExampleService publishes once their transaction
+// commits, on every replica. The outbox id is the revision of a change:
+// resume tokens encode it, and watchers resuming from an older revision, or
+// falling behind the live feed, catch up by reading the outbox. A slow
+// watcher therefore never blocks the feed or misses changes.
+//
+// Outbox ids are allocated before their transaction commits, so a missing id
+// may still show up. The feed waits GapTimeout for it, then moves past it and
+// keeps looking it up for GapRetention; a change committing that late is sent
+// to the live watchers out of revision order.
+type ChangeFeed struct {
+	outbox repositories.OutboxRepository
+	cfg    ChangeFeedConfig
+
+	mu       sync.Mutex
+	started  bool
+	ready    chan struct{}
+	done     chan struct{}
+	revision int64
+	gapSince time.Time
+	// skipped holds the ids the feed moved past and when it did
+	skipped  map[int64]time.Time
+	watchers map[*changeWatcher]struct{}
+}
+
+// NewChangeFeed creates a new change feed
+func NewChangeFeed(outbox repositories.OutboxRepository, cfg ChangeFeedConfig) *ChangeFeed {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 200 * time.Millisecond
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	if cfg.GapTimeout <= 0 {
+		cfg.GapTimeout = time.Second
+	}
+	if cfg.GapRetention <= 0 {
+		cfg.GapRetention = time.Minute
+	}
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 256
+	}
+
+	return &ChangeFeed{
+		outbox:   outbox,
+		cfg:      cfg,
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
+		skipped:  make(map[int64]time.Time),
+		watchers: make(map[*changeWatcher]struct{}),
+	}
+}
+
+var _ services.ExampleWatcher = (*ChangeFeed)(nil)
+
+// change is an example change and its revision
+type change struct {
+	revision int64
+	// late is set when the change committed after the feed moved past it
+	late bool
+	*dto.ExampleChange
+}
+
+// changeWatcher is one WatchExamples call
+type changeWatcher struct {
+	ids    map[int64]bool
+	status string
+	ch     chan change
+	// lagged is set when a change was dropped because ch was full
+	lagged atomic.Bool
+}
+
+// matches reports whether the watcher wants the change
+func (w *changeWatcher) matches(c change) bool {
+	if len(w.ids) > 0 && !w.ids[c.ExampleID] {
+		return false
+	}
+	return w.status == "" || w.status == c.Status
+}
+
+// Run follows the outbox until ctx is cancelled, then ends every watch with
+// domain.ErrChangeFeedStopped
+func (f *ChangeFeed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.cfg.PollInterval)
+	defer ticker.Stop()
+	defer close(f.done)
+
+	for {
+		n, err := f.Poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[ChangeFeed] failed to read outbox: %v", err)
+		}
+
+		// Keep reading while batches come back full
+		if err == nil && n == f.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll reads new outbox events and sends them to the watchers, returning how
+// many events were sent. The first call starts the feed at the newest event.
+func (f *ChangeFeed) Poll(ctx context.Context) (int, error) {
+	if !f.started {
+		last, err := f.outbox.LastID(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get newest outbox event: %w", err)
+		}
+		f.mu.Lock()
+		f.revision = last
+		f.mu.Unlock()
+		f.started = true
+		close(f.ready)
+	}
+
+	sent, err := f.recheck(ctx)
+	if err != nil {
+		return sent, err
+	}
+
+	messages, err := f.outbox.ListAfter(ctx, f.current(), f.cfg.BatchSize)
+	if err != nil {
+		return sent, fmt.Errorf("failed to list outbox events: %w", err)
+	}
+
+	for _, msg := range messages {
+		current := f.current()
+		if msg.ID != current+1 {
+			// An earlier id may still be committing; wait for it to show up
+			// for a while before moving past it
+			if f.gapSince.IsZero() {
+				f.gapSince = time.Now()
+			}
+			if time.Since(f.gapSince) < f.cfg.GapTimeout {
+				break
+			}
+			f.skip(current+1, msg.ID)
+		}
+		f.gapSince = time.Time{}
+		f.broadcast(msg.ID, toChange(msg))
+		sent++
+	}
+	return sent, nil
+}
+
+// skip records the ids from first up to, but not including, next as skipped.
+// At most BatchSize ids are kept; older ones are forgotten first.
+func (f *ChangeFeed) skip(first, next int64) {
+	now := time.Now()
+	for id := max(first, next-int64(f.cfg.BatchSize)); id < next; id++ {
+		f.skipped[id] = now
+	}
+	for len(f.skipped) > f.cfg.BatchSize {
+		delete(f.skipped, slices.Min(slices.Collect(maps.Keys(f.skipped))))
+	}
+}
+
+// recheck looks up the skipped ids, sends the changes that have committed
+// since to the watchers and returns how many it sent. Ids skipped longer
+// than GapRetention ago are assumed to be rolled back and forgotten.
+func (f *ChangeFeed) recheck(ctx context.Context) (int, error) {
+	if len(f.skipped) == 0 {
+		return 0, nil
+	}
+	ids := make([]int64, 0, len(f.skipped))
+	for id, since := range f.skipped {
+		if time.Since(since) >= f.cfg.GapRetention {
+			delete(f.skipped, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	messages, err := f.outbox.ListByIDs(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up skipped outbox events: %w", err)
+	}
+	for _, msg := range messages {
+		delete(f.skipped, msg.ID)
+		c := toChange(msg)
+		c.late = true
+		f.send(c)
+	}
+	return len(messages), nil
+}
+
+// WatchExamples calls fn with each change matching the request, in order,
+// until ctx is cancelled or fn returns an error. Without a resume token it
+// starts with the next change.
+func (f *ChangeFeed) WatchExamples(ctx context.Context, req *dto.WatchExamplesRequest, fn func(*dto.ExampleChange) error) error {
+	if err := f.ValidateWatch(req); err != nil {
+		return err
+	}
+	last, resume, _ := decodeResumeToken(req.ResumeToken)
+
+	select {
+	case <-f.ready:
+	case <-f.done:
+		return domain.ErrChangeFeedStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	w := &changeWatcher{
+		status: req.Status,
+		ch:     make(chan change, f.cfg.Buffer),
+	}
+	if len(req.IDs) > 0 {
+		w.ids = make(map[int64]bool, len(req.IDs))
+		for _, id := range req.IDs {
+			w.ids[id] = true
+		}
+	}
+
+	current := f.register(w)
+	defer f.unregister(w)
+
+	// catchUp replays the changes after last up to the given revision from
+	// the outbox
+	catchUp := func(upTo int64) error {
+		for last < upTo {
+			messages, err := f.outbox.ListAfter(ctx, last, f.cfg.BatchSize)
+			if err != nil {
+				return fmt.Errorf("failed to list outbox events: %w", err)
+			}
+			if len(messages) == 0 {
+				break
+			}
+			for _, msg := range messages {
+				if msg.ID > upTo {
+					last = upTo
+					return nil
+				}
+				last = msg.ID
+				if c := toChange(msg); c.ExampleChange != nil && w.matches(c) {
+					if err := fn(c.ExampleChange); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		last = max(last, upTo)
+		return nil
+	}
+
+	if !resume {
+		last = current
+	} else if err := catchUp(current); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.done:
+			return domain.ErrChangeFeedStopped
+		case c := <-w.ch:
+			if w.lagged.Swap(false) {
+				// Changes were dropped; everything up to the feed's position,
+				// including c, is read from the outbox instead
+				if err := catchUp(f.current()); err != nil {
+					return err
+				}
+				continue
+			}
+			if c.late {
+				// The change is older than ones already sent; resuming from
+				// it must not replay those
+				late := *c.ExampleChange
+				late.ResumeToken = encodeResumeToken(last)
+				if err := fn(&late); err != nil {
+					return err
+				}
+				continue
+			}
+			if c.revision <= last {
+				continue
+			}
+			last = c.revision
+			if err := fn(c.ExampleChange); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ValidateWatch checks the filters and resume token of a watch request
+func (f *ChangeFeed) ValidateWatch(req *dto.WatchExamplesRequest) error {
+	if err := validate(req); err != nil {
+		return err
+	}
+	_, _, err := decodeResumeToken(req.ResumeToken)
+	return err
+}
+
+// current returns the revision of the newest change sent to watchers
+func (f *ChangeFeed) current() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.revision
+}
+
+// register adds a watcher and returns the feed's revision; every later
+// change is sent to the watcher
+func (f *ChangeFeed) register(w *changeWatcher) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.watchers[w] = struct{}{}
+	return f.revision
+}
+
+// unregister removes a watcher
+func (f *ChangeFeed) unregister(w *changeWatcher) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.watchers, w)
+}
+
+// broadcast advances the feed to a revision and queues its change for the
+// interested watchers without waiting for any of them
+func (f *ChangeFeed) broadcast(revision int64, c change) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.revision = revision
+	f.queue(c)
+}
+
+// send queues a change for the interested watchers without advancing the
+// feed
+func (f *ChangeFeed) send(c change) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queue(c)
+}
+
+// queue queues a change for the interested watchers; f.mu must be held
+func (f *ChangeFeed) queue(c change) {
+	if c.ExampleChange == nil {
+		return
+	}
+	for w := range f.watchers {
+		if !w.matches(c) {
+			continue
+		}
+		select {
+		case w.ch <- c:
+		default:
+			w.lagged.Store(true)
+		}
+	}
+}
+
+// changePayload holds the fields of the example event payloads
+type changePayload struct {
+	ExampleID int64  `json:"example_id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+}
+
+// toChange converts an outbox message to an example change. Messages that
+// are not example events have no change.
+func toChange(msg *domain.OutboxMessage) change {
+	var changeType string
+	switch msg.EventType {
+	case domain.EventTypeExampleCreated:
+		changeType = dto.ChangeCreated
+	case domain.EventTypeExampleUpdated:
+		changeType = dto.ChangeUpdated
+	case domain.EventTypeExampleDeleted:
+		changeType = dto.ChangeDeleted
+	default:
+		return change{revision: msg.ID}
+	}
+
+	var payload changePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		log.Printf("[ChangeFeed] skipping undecodable %s event %d: %v", msg.EventType, msg.ID, err)
+		return change{revision: msg.ID}
+	}
+
+	event := outboxEvent(msg)
+	return change{
+		revision: msg.ID,
+		ExampleChange: &dto.ExampleChange{
+			Type:        changeType,
+			ExampleID:   payload.ExampleID,
+			Name:        payload.Name,
+			Status:      payload.Status,
+			EventID:     event.ID,
+			OccurredAt:  msg.OccurredAt.UTC().Format(time.RFC3339Nano),
+			ResumeToken: encodeResumeToken(msg.ID),
+		},
+	}
+}
+
+// encodeResumeToken encodes a revision as an opaque token
+func encodeResumeToken(revision int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("r" + strconv.FormatInt(revision, 10)))
+}
+
+// decodeResumeToken decodes a token produced by encodeResumeToken. An empty
+// token reports ok=false.
+func decodeResumeToken(token string) (revision int64, ok bool, err error) {
+	if token == "" {
+		return 0, false, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil && len(b) > 1 && b[0] == 'r' {
+		revision, err = strconv.ParseInt(string(b[1:]), 10, 64)
+		if err == nil && revision >= 0 {
+			return revision, true, nil
+		}
+	}
+	return 0, false, invalidField("resume_token", "resume_token is invalid")
+}
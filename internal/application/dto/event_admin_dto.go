@@ -0,0 +1,44 @@
+package dto
+
+// DeadLetterResponse represents an event the outbox relay gave up on
+type DeadLetterResponse struct {
+	ID             int64  `json:"id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	AggregateID    int64  `json:"aggregate_id"`
+	Attempts       int    `json:"attempts"`
+	LastError      string `json:"last_error"`
+	OccurredAt     string `json:"occurred_at"`
+	DeadLetteredAt string `json:"dead_lettered_at"`
+}
+
+// ListDeadLettersResponse represents the most recently parked events and
+// the number of parked events in total
+type ListDeadLettersResponse struct {
+	DeadLetters []*DeadLetterResponse `json:"dead_letters"`
+	Total       int64                 `json:"total"`
+}
+
+// PublishStatsResponse represents the event publishing counters of this
+// instance since it started, the events parked across all instances and the
+// counters of the in-process event bus subscribers
+type PublishStatsResponse struct {
+	Published    int64                      `json:"published"`
+	Failed       int64                      `json:"failed"`
+	DeadLettered int64                      `json:"dead_lettered"`
+	Replayed     int64                      `json:"replayed"`
+	Parked       int64                      `json:"parked"`
+	Subscribers  []*SubscriberStatsResponse `json:"subscribers"`
+}
+
+// SubscriberStatsResponse represents the delivery counters of an event bus
+// subscriber on this instance
+type SubscriberStatsResponse struct {
+	Name      string `json:"name"`
+	Mode      string `json:"mode"`
+	Delivered uint64 `json:"delivered"`
+	Failed    uint64 `json:"failed"`
+	Panics    uint64 `json:"panics"`
+	Dropped   uint64 `json:"dropped"`
+	LastError string `json:"last_error,omitempty"`
+}
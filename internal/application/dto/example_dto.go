@@ -3,12 +3,41 @@ package dto
 // CreateExampleRequest represents the request to create an example
 type CreateExampleRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=255"`
+
+	// AccountID is the account that owns the example, if any
+	AccountID string `json:"account_id" validate:"omitempty,max=255"`
 }
 
 // UpdateExampleRequest represents the request to update an example
 type UpdateExampleRequest struct {
 	Name   string `json:"name" validate:"omitempty,min=1,max=255"`
 	Status string `json:"status" validate:"omitempty,oneof=active inactive"`
+
+	// ExpectedVersion, when set, makes the update fail with a version
+	// conflict unless the example is still at this version
+	ExpectedVersion *int64 `json:"-"`
+}
+
+// ListExamplesRequest represents the request to list examples. CreatedAfter
+// and CreatedBefore are RFC 3339 timestamps; OrderBy is a field name
+// optionally followed by "desc", e.g. "created_at desc". PageSize defaults
+// to 50 and larger pages are capped at 100.
+type ListExamplesRequest struct {
+	PageSize      int32  `json:"page_size" validate:"min=0"`
+	PageToken     string `json:"page_token"`
+	Status        string `json:"status" validate:"omitempty,oneof=active inactive"`
+	NamePrefix    string `json:"name_prefix" validate:"omitempty,max=255"`
+	CreatedAfter  string `json:"created_after"`
+	CreatedBefore string `json:"created_before"`
+	OrderBy       string `json:"order_by"`
+	IncludeTotal  bool   `json:"include_total"`
+}
+
+// ListExamplesResponse represents one page of examples
+type ListExamplesResponse struct {
+	Examples      []*ExampleResponse `json:"examples"`
+	NextPageToken string             `json:"next_page_token"`
+	TotalCount    *int64             `json:"total_count,omitempty"`
 }
 
 // ExampleResponse represents the example response
@@ -16,7 +45,36 @@ type ExampleResponse struct {
 	ID        int64  `json:"id"`
 	Name      string `json:"name"`
 	Status    string `json:"status"`
+	AccountID string `json:"account_id"`
+	Version   int64  `json:"version"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
 
+// Example change types
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// WatchExamplesRequest represents the request to watch example changes.
+// IDs and Status filter the changes; ResumeToken, taken from a previously
+// received change, replays the changes made after it.
+type WatchExamplesRequest struct {
+	IDs         []int64 `json:"ids"`
+	Status      string  `json:"status" validate:"omitempty,oneof=active inactive"`
+	ResumeToken string  `json:"resume_token"`
+}
+
+// ExampleChange represents a created, updated or deleted example. Status
+// is the status after the change, or at deletion.
+type ExampleChange struct {
+	Type        string `json:"type"`
+	ExampleID   int64  `json:"example_id"`
+	Name        string `json:"name,omitempty"`
+	Status      string `json:"status,omitempty"`
+	EventID     string `json:"event_id"`
+	OccurredAt  string `json:"occurred_at"`
+	ResumeToken string `json:"resume_token"`
+}
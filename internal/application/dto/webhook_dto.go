@@ -0,0 +1,49 @@
+package dto
+
+// CreateWebhookRequest represents the request to subscribe a webhook
+// endpoint. EventTypes filters the delivered events, all events when empty.
+// A secret is generated when none is given.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,max=2048"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255"`
+}
+
+// WebhookResponse represents a webhook subscription. Secret is only set in
+// the response to its creation.
+type WebhookResponse struct {
+	ID                  int64    `json:"id"`
+	URL                 string   `json:"url"`
+	EventTypes          []string `json:"event_types"`
+	Secret              string   `json:"secret,omitempty"`
+	Active              bool     `json:"active"`
+	ConsecutiveFailures int      `json:"consecutive_failures"`
+	DisabledAt          string   `json:"disabled_at,omitempty"`
+	CreatedAt           string   `json:"created_at"`
+}
+
+// ListWebhooksResponse represents all webhook subscriptions
+type ListWebhooksResponse struct {
+	Webhooks []*WebhookResponse `json:"webhooks"`
+}
+
+// WebhookDeliveryResponse represents one entry of a delivery log
+type WebhookDeliveryResponse struct {
+	ID             int64  `json:"id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	AggregateID    int64  `json:"aggregate_id"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// ListWebhookDeliveriesResponse represents the most recent deliveries of a
+// subscription, newest first
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"example-service/internal/domain"
+	apperrors "example-service/pkg/errors"
+	"example-service/pkg/validator"
+	"net/http"
+)
+
+// MapError translates errors returned by the application services into
+// application errors that are safe to expose to clients. Transports use it so
+// that HTTP and gRPC report the same code and message for the same failure.
+func MapError(err error) *apperrors.AppError {
+	if appErr, ok := apperrors.As(err); ok {
+		return appErr
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrExampleNotFound):
+		return apperrors.Wrap(apperrors.CodeNotFound, "example not found", http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		return apperrors.Wrap(apperrors.CodeNotFound, "webhook subscription not found", http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrDeadLetterNotFound):
+		return apperrors.Wrap(apperrors.CodeNotFound, "dead-lettered event not found", http.StatusNotFound, err)
+	case errors.Is(err, domain.ErrExampleAlreadyExists):
+		return apperrors.Wrap(apperrors.CodeConflict, "example already exists", http.StatusConflict, err)
+	case errors.Is(err, domain.ErrVersionConflict):
+		return apperrors.Wrap(apperrors.CodePreconditionFailed, "example was modified by another request", http.StatusPreconditionFailed, err)
+	case errors.Is(err, domain.ErrInvalidInput):
+		return apperrors.Wrap(apperrors.CodeInvalidInput, err.Error(), http.StatusBadRequest, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return apperrors.Wrap(apperrors.CodeTimeout, "deadline exceeded", http.StatusGatewayTimeout, err)
+	case errors.Is(err, domain.ErrEventPublishFailed):
+		return apperrors.Wrap(apperrors.CodeUnavailable, "event broker unavailable", http.StatusServiceUnavailable, err)
+	case errors.Is(err, domain.ErrChangeFeedStopped):
+		return apperrors.Wrap(apperrors.CodeUnavailable, "service is shutting down", http.StatusServiceUnavailable, err)
+	case errors.Is(err, driver.ErrBadConn):
+		return apperrors.Wrap(apperrors.CodeUnavailable, "service temporarily unavailable", http.StatusServiceUnavailable, err)
+	case errors.Is(err, context.Canceled):
+		return apperrors.Wrap(apperrors.CodeCanceled, "request canceled", apperrors.StatusClientClosedRequest, err)
+	default:
+		return apperrors.Wrap(apperrors.CodeInternal, "Internal server error", http.StatusInternalServerError, err)
+	}
+}
+
+// validate checks a request against its validate struct tags and reports
+// every failing field as an invalid input error
+func validate(req interface{}) error {
+	err := validator.ValidateStruct(req)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	fields := make([]apperrors.FieldViolation, len(verrs))
+	for i, fe := range verrs {
+		fields[i] = apperrors.FieldViolation{Field: fe.Field, Description: fe.Message}
+	}
+	return apperrors.Wrap(apperrors.CodeInvalidInput, verrs.Error(), http.StatusBadRequest, domain.ErrInvalidInput).
+		WithFields(fields...)
+}
+
+// invalidField builds an invalid input error for a single request field
+func invalidField(field, description string) error {
+	return apperrors.Wrap(apperrors.CodeInvalidInput, description, http.StatusBadRequest, domain.ErrInvalidInput).
+		WithFields(apperrors.FieldViolation{Field: field, Description: description})
+}
@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"example-service/internal/ports/repositories"
+	"example-service/internal/ports/services"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultDeadLetterLimit = 50
+	maxDeadLetterLimit     = 500
+)
+
+// PublishMetrics counts event publishing outcomes. It is shared by the
+// publishers and the relay of an instance.
+type PublishMetrics struct {
+	published    atomic.Int64
+	failed       atomic.Int64
+	deadLettered atomic.Int64
+	replayed     atomic.Int64
+}
+
+// NewPublishMetrics creates zeroed publishing counters
+func NewPublishMetrics() *PublishMetrics {
+	return &PublishMetrics{}
+}
+
+// EventAdminService implements the event administration use cases
+type EventAdminService struct {
+	outbox      repositories.OutboxRepository
+	metrics     *PublishMetrics
+	subscribers external.SubscriberStatsReporter
+}
+
+// NewEventAdminService creates a new event administration service. The
+// publish stats include the counters of subscribers, which may be nil.
+func NewEventAdminService(outbox repositories.OutboxRepository, metrics *PublishMetrics, subscribers external.SubscriberStatsReporter) services.EventAdminService {
+	return &EventAdminService{
+		outbox:      outbox,
+		metrics:     metrics,
+		subscribers: subscribers,
+	}
+}
+
+// ListDeadLetters retrieves the most recently parked events. A limit of zero
+// returns the default page size.
+func (s *EventAdminService) ListDeadLetters(ctx context.Context, limit int) (*dto.ListDeadLettersResponse, error) {
+	if limit < 0 || limit > maxDeadLetterLimit {
+		return nil, invalidField("limit", fmt.Sprintf("limit must be between 0 and %d", maxDeadLetterLimit))
+	}
+	if limit == 0 {
+		limit = defaultDeadLetterLimit
+	}
+
+	messages, err := s.outbox.ListDeadLettered(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead-lettered events: %w", err)
+	}
+	total, err := s.outbox.CountDeadLettered(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dead-lettered events: %w", err)
+	}
+
+	resp := &dto.ListDeadLettersResponse{
+		DeadLetters: make([]*dto.DeadLetterResponse, len(messages)),
+		Total:       total,
+	}
+	for i, msg := range messages {
+		resp.DeadLetters[i] = deadLetterToDTO(msg)
+	}
+	return resp, nil
+}
+
+// ReplayDeadLetter makes a parked event due again; the relay publishes it
+// with its next batch
+func (s *EventAdminService) ReplayDeadLetter(ctx context.Context, id int64) error {
+	ok, err := s.outbox.Requeue(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to requeue event: %w", err)
+	}
+	if !ok {
+		return domain.ErrDeadLetterNotFound
+	}
+	s.metrics.replayed.Add(1)
+	return nil
+}
+
+// GetPublishStats retrieves the publishing and event bus counters of this
+// instance and the number of parked events
+func (s *EventAdminService) GetPublishStats(ctx context.Context) (*dto.PublishStatsResponse, error) {
+	parked, err := s.outbox.CountDeadLettered(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count dead-lettered events: %w", err)
+	}
+	resp := &dto.PublishStatsResponse{
+		Published:    s.metrics.published.Load(),
+		Failed:       s.metrics.failed.Load(),
+		DeadLettered: s.metrics.deadLettered.Load(),
+		Replayed:     s.metrics.replayed.Load(),
+		Parked:       parked,
+		Subscribers:  []*dto.SubscriberStatsResponse{},
+	}
+	if s.subscribers != nil {
+		for _, stats := range s.subscribers.Stats() {
+			resp.Subscribers = append(resp.Subscribers, &dto.SubscriberStatsResponse{
+				Name:      stats.Name,
+				Mode:      stats.Mode,
+				Delivered: stats.Delivered,
+				Failed:    stats.Failed,
+				Panics:    stats.Panics,
+				Dropped:   stats.Dropped,
+				LastError: stats.LastError,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// deadLetterToDTO converts a parked outbox message to a response
+func deadLetterToDTO(msg *domain.OutboxMessage) *dto.DeadLetterResponse {
+	resp := &dto.DeadLetterResponse{
+		ID:          msg.ID,
+		EventID:     outboxEvent(msg).ID,
+		EventType:   msg.EventType,
+		AggregateID: msg.AggregateID,
+		Attempts:    msg.Attempts,
+		LastError:   msg.LastError,
+		OccurredAt:  msg.OccurredAt.Format(time.RFC3339),
+	}
+	if msg.DeadLetteredAt != nil {
+		resp.DeadLetteredAt = msg.DeadLetteredAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"log"
+)
+
+// EventHandlerFunc handles one type of inbound event
+type EventHandlerFunc func(ctx context.Context, event *domain.InboundEvent) error
+
+// EventDispatcher implements the event handler port by routing inbound
+// events to the handler registered for their type. Each event is handled at
+// most once per consumer: the handler's changes and the processed marker are
+// committed in one transaction, so a redelivered event is skipped and a
+// failed one is retried from scratch.
+type EventDispatcher struct {
+	consumer   string
+	processed  repositories.ProcessedEventRepository
+	transactor repositories.Transactor
+	handlers   map[string]EventHandlerFunc
+}
+
+// NewEventDispatcher creates a dispatcher recording processed events under
+// the consumer name. A nil transactor runs handlers without a transaction.
+func NewEventDispatcher(
+	consumer string,
+	processed repositories.ProcessedEventRepository,
+	transactor repositories.Transactor,
+) *EventDispatcher {
+	return &EventDispatcher{
+		consumer:   consumer,
+		processed:  processed,
+		transactor: transactor,
+		handlers:   make(map[string]EventHandlerFunc),
+	}
+}
+
+// Register sets the handler for an event type, replacing any previous one
+func (d *EventDispatcher) Register(eventType string, handler EventHandlerFunc) {
+	d.handlers[eventType] = handler
+}
+
+// Handle registers a typed handler: the JSON data of each event is decoded
+// into T before fn is called. Undecodable data is a permanent failure.
+func Handle[T any](d *EventDispatcher, eventType string, fn func(ctx context.Context, event *domain.InboundEvent, payload T) error) {
+	d.Register(eventType, func(ctx context.Context, event *domain.InboundEvent) error {
+		var payload T
+		if err := json.Unmarshal(event.Data, &payload); err != nil {
+			return fmt.Errorf("%w: failed to decode %s event: %v", domain.ErrInvalidInput, event.Type, err)
+		}
+		return fn(ctx, event, payload)
+	})
+}
+
+// EventTypes returns the registered event types
+func (d *EventDispatcher) EventTypes() []string {
+	types := make([]string, 0, len(d.handlers))
+	for eventType := range d.handlers {
+		types = append(types, eventType)
+	}
+	return types
+}
+
+// HandleEvent processes an event once. Events without a registered handler
+// are ignored.
+func (d *EventDispatcher) HandleEvent(ctx context.Context, event *domain.InboundEvent) error {
+	handler, ok := d.handlers[event.Type]
+	if !ok {
+		return nil
+	}
+	if event.ID == "" {
+		return fmt.Errorf("%w: event has no id", domain.ErrInvalidInput)
+	}
+
+	// CloudEvents ids are only unique per source
+	eventID := event.Source + "/" + event.ID
+
+	err := d.inTransaction(ctx, func(ctx context.Context) error {
+		done, err := d.processed.IsProcessed(ctx, d.consumer, eventID)
+		if err != nil {
+			return fmt.Errorf("failed to check processed events: %w", err)
+		}
+		if done {
+			return domain.ErrEventProcessed
+		}
+
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+
+		return d.processed.MarkProcessed(ctx, &domain.ProcessedEvent{
+			Consumer:  d.consumer,
+			EventID:   eventID,
+			EventType: event.Type,
+		})
+	})
+	if errors.Is(err, domain.ErrEventProcessed) {
+		log.Printf("[EventDispatcher] skipping duplicate %s event %s", event.Type, eventID)
+		return nil
+	}
+	return err
+}
+
+// inTransaction runs fn inside a transaction when a transactor is configured
+func (d *EventDispatcher) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if d.transactor == nil {
+		return fn(ctx)
+	}
+	return d.transactor.WithinTransaction(ctx, fn)
+}
@@ -0,0 +1,21 @@
+package application
+
+import (
+	"context"
+	"example-service/internal/domain"
+	"example-service/internal/ports/services"
+	"log"
+)
+
+// RegisterEventHandlers registers the handlers for the events this service
+// consumes: closing an account deactivates the examples it owns
+func RegisterEventHandlers(d *EventDispatcher, exampleService services.ExampleService) {
+	Handle(d, domain.EventTypeAccountClosed, func(ctx context.Context, event *domain.InboundEvent, payload domain.AccountClosedEvent) error {
+		deactivated, err := exampleService.DeactivateAccountExamples(ctx, payload.AccountID)
+		if err != nil {
+			return err
+		}
+		log.Printf("[EventDispatcher] deactivated %d examples of closed account %s", deactivated, payload.AccountID)
+		return nil
+	})
+}
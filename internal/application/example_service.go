@@ -1,6 +1,8 @@
 package application
 
 import (
+	"context"
+	"errors"
 	"example-service/internal/application/dto"
 	"example-service/internal/domain"
 	"example-service/internal/ports/external"
@@ -12,54 +14,58 @@ import (
 
 // ExampleService implements the example service interface
 type ExampleService struct {
-	exampleRepo   repositories.ExampleRepository
+	exampleRepo    repositories.ExampleRepository
 	eventPublisher external.EventPublisher
+	transactor     repositories.Transactor
 }
 
-// NewExampleService creates a new example service
+// NewExampleService creates a new example service. When a transactor is
+// given, each change and the events it publishes are written in one
+// transaction, which together with the outbox publisher guarantees that no
+// event is lost.
 func NewExampleService(
 	exampleRepo repositories.ExampleRepository,
 	eventPublisher external.EventPublisher,
+	transactor repositories.Transactor,
 ) services.ExampleService {
 	return &ExampleService{
-		exampleRepo:   exampleRepo,
+		exampleRepo:    exampleRepo,
 		eventPublisher: eventPublisher,
+		transactor:     transactor,
 	}
 }
 
 // CreateExample creates a new example
-func (s *ExampleService) CreateExample(req *dto.CreateExampleRequest) (*dto.ExampleResponse, error) {
-	// Check if example already exists
-	exists, err := s.exampleRepo.Exists(req.Name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check if example exists: %w", err)
-	}
-	if exists {
-		return nil, domain.ErrExampleAlreadyExists
+func (s *ExampleService) CreateExample(ctx context.Context, req *dto.CreateExampleRequest) (*dto.ExampleResponse, error) {
+	if err := validate(req); err != nil {
+		return nil, err
 	}
 
 	// Create domain entity
-	now := time.Now()
+	now := time.Now().UTC()
 	example := &domain.Example{
 		Name:      req.Name,
 		Status:    "active",
+		AccountID: req.AccountID,
+		Version:   1,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
-	// Save to repository
-	if err := s.exampleRepo.Create(example); err != nil {
-		return nil, fmt.Errorf("failed to create example: %w", err)
-	}
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		// Save to repository; the unique name index rejects duplicates
+		if err := s.exampleRepo.Create(ctx, example); err != nil {
+			return err
+		}
 
-	// Publish event
-	if s.eventPublisher != nil {
-		event := &domain.Event{
-			Type:      "ExampleCreated",
-			Payload:   domain.ExampleCreatedEvent{ExampleID: example.ID, Name: example.Name, Timestamp: now},
-			Timestamp: now,
+		return s.publish(ctx, domain.NewExampleEvent(domain.EventTypeExampleCreated, example.ID,
+			domain.ExampleCreatedEvent{ExampleID: example.ID, Name: example.Name, Status: example.Status, Timestamp: now}, now))
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrExampleAlreadyExists) {
+			return nil, err
 		}
-		_ = s.eventPublisher.Publish(event) // Log error but don't fail
+		return nil, fmt.Errorf("failed to create example: %w", err)
 	}
 
 	// Return response
@@ -67,8 +73,8 @@ func (s *ExampleService) CreateExample(req *dto.CreateExampleRequest) (*dto.Exam
 }
 
 // GetExample retrieves an example by ID
-func (s *ExampleService) GetExample(id int64) (*dto.ExampleResponse, error) {
-	example, err := s.exampleRepo.FindByID(id)
+func (s *ExampleService) GetExample(ctx context.Context, id int64) (*dto.ExampleResponse, error) {
+	example, err := s.exampleRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get example: %w", err)
 	}
@@ -79,31 +85,64 @@ func (s *ExampleService) GetExample(id int64) (*dto.ExampleResponse, error) {
 	return s.toDTO(example), nil
 }
 
-// ListExamples retrieves all examples
-func (s *ExampleService) ListExamples() ([]*dto.ExampleResponse, error) {
-	examples, err := s.exampleRepo.FindAll()
+// ListExamples retrieves one page of examples
+func (s *ExampleService) ListExamples(ctx context.Context, req *dto.ListExamplesRequest) (*dto.ListExamplesResponse, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	query, err := buildExampleQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row to know whether another page follows
+	limit := query.Limit
+	query.Limit = limit + 1
+	examples, err := s.exampleRepo.List(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list examples: %w", err)
 	}
 
-	dtos := make([]*dto.ExampleResponse, len(examples))
+	resp := &dto.ListExamplesResponse{}
+	if len(examples) > limit {
+		examples = examples[:limit]
+		resp.NextPageToken = encodePageToken(query, examples[limit-1])
+	}
+
+	resp.Examples = make([]*dto.ExampleResponse, len(examples))
 	for i, example := range examples {
-		dtos[i] = s.toDTO(example)
+		resp.Examples[i] = s.toDTO(example)
+	}
+
+	if req.IncludeTotal {
+		total, err := s.exampleRepo.Count(ctx, query.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count examples: %w", err)
+		}
+		resp.TotalCount = &total
 	}
 
-	return dtos, nil
+	return resp, nil
 }
 
 // UpdateExample updates an existing example
-func (s *ExampleService) UpdateExample(id int64, req *dto.UpdateExampleRequest) (*dto.ExampleResponse, error) {
+func (s *ExampleService) UpdateExample(ctx context.Context, id int64, req *dto.UpdateExampleRequest) (*dto.ExampleResponse, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
 	// Get existing example
-	example, err := s.exampleRepo.FindByID(id)
+	example, err := s.exampleRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get example: %w", err)
 	}
 	if example == nil {
 		return nil, domain.ErrExampleNotFound
 	}
+	if req.ExpectedVersion != nil && *req.ExpectedVersion != example.Version {
+		return nil, domain.ErrVersionConflict
+	}
 
 	// Update fields
 	if req.Name != "" {
@@ -112,30 +151,33 @@ func (s *ExampleService) UpdateExample(id int64, req *dto.UpdateExampleRequest)
 	if req.Status != "" {
 		example.Status = req.Status
 	}
-	example.UpdatedAt = time.Now()
+	now := time.Now().UTC()
+	example.UpdatedAt = now
 
-	// Save to repository
-	if err := s.exampleRepo.Update(example); err != nil {
-		return nil, fmt.Errorf("failed to update example: %w", err)
-	}
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		// Save to repository; fails if the example changed since it was
+		// read or the new name is taken
+		if err := s.exampleRepo.Update(ctx, example); err != nil {
+			return err
+		}
 
-	// Publish event
-	if s.eventPublisher != nil {
-		event := &domain.Event{
-			Type:      "ExampleUpdated",
-			Payload:   domain.ExampleUpdatedEvent{ExampleID: example.ID, Name: example.Name, Timestamp: time.Now()},
-			Timestamp: time.Now(),
+		return s.publish(ctx, domain.NewExampleEvent(domain.EventTypeExampleUpdated, example.ID,
+			domain.ExampleUpdatedEvent{ExampleID: example.ID, Name: example.Name, Status: example.Status, Timestamp: now}, now))
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) || errors.Is(err, domain.ErrExampleAlreadyExists) {
+			return nil, err
 		}
-		_ = s.eventPublisher.Publish(event)
+		return nil, fmt.Errorf("failed to update example: %w", err)
 	}
 
 	return s.toDTO(example), nil
 }
 
 // DeleteExample deletes an example by ID
-func (s *ExampleService) DeleteExample(id int64) error {
+func (s *ExampleService) DeleteExample(ctx context.Context, id int64) error {
 	// Check if example exists
-	example, err := s.exampleRepo.FindByID(id)
+	example, err := s.exampleRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get example: %w", err)
 	}
@@ -143,21 +185,84 @@ func (s *ExampleService) DeleteExample(id int64) error {
 		return domain.ErrExampleNotFound
 	}
 
-	// Delete from repository
-	if err := s.exampleRepo.Delete(id); err != nil {
+	now := time.Now().UTC()
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		// Delete from repository
+		if err := s.exampleRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.publish(ctx, domain.NewExampleEvent(domain.EventTypeExampleDeleted, id,
+			domain.ExampleDeletedEvent{ExampleID: id, Status: example.Status, Timestamp: now}, now))
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete example: %w", err)
 	}
 
-	// Publish event
-	if s.eventPublisher != nil {
-		event := &domain.Event{
-			Type:      "ExampleDeleted",
-			Payload:   domain.ExampleDeletedEvent{ExampleID: id, Timestamp: time.Now()},
-			Timestamp: time.Now(),
+	return nil
+}
+
+// DeactivateAccountExamples deactivates the active examples owned by an
+// account, publishing an update event for each, and returns how many were
+// deactivated. All of them change in one transaction.
+func (s *ExampleService) DeactivateAccountExamples(ctx context.Context, accountID string) (int, error) {
+	if accountID == "" {
+		return 0, fmt.Errorf("%w: account id is required", domain.ErrInvalidInput)
+	}
+
+	query := repositories.ExampleQuery{
+		Filter: repositories.ExampleFilter{AccountID: accountID, Status: "active"},
+		Limit:  maxPageSize,
+	}
+	deactivated := 0
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		// Deactivated examples leave the filter, so the first page is
+		// read until it comes back empty
+		for {
+			examples, err := s.exampleRepo.List(ctx, query)
+			if err != nil {
+				return err
+			}
+			if len(examples) == 0 {
+				return nil
+			}
+			for _, example := range examples {
+				example.Deactivate()
+				if err := s.exampleRepo.Update(ctx, example); err != nil {
+					return err
+				}
+				err := s.publish(ctx, domain.NewExampleEvent(domain.EventTypeExampleUpdated, example.ID,
+					domain.ExampleUpdatedEvent{ExampleID: example.ID, Name: example.Name, Status: example.Status, Timestamp: example.UpdatedAt}, example.UpdatedAt))
+				if err != nil {
+					return err
+				}
+				deactivated++
+			}
 		}
-		_ = s.eventPublisher.Publish(event)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to deactivate examples of account %s: %w", accountID, err)
+	}
+	return deactivated, nil
+}
+
+// inTransaction runs fn inside a transaction when a transactor is configured
+func (s *ExampleService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transactor == nil {
+		return fn(ctx)
 	}
+	return s.transactor.WithinTransaction(ctx, fn)
+}
 
+// publish publishes a domain event. With the outbox publisher the event is
+// stored in the current transaction, so a failure rolls back the change.
+func (s *ExampleService) publish(ctx context.Context, event *domain.Event) error {
+	if s.eventPublisher == nil {
+		return nil
+	}
+	if err := s.eventPublisher.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
 	return nil
 }
 
@@ -167,8 +272,9 @@ func (s *ExampleService) toDTO(example *domain.Example) *dto.ExampleResponse {
 		ID:        example.ID,
 		Name:      example.Name,
 		Status:    example.Status,
+		AccountID: example.AccountID,
+		Version:   example.Version,
 		CreatedAt: example.CreatedAt.Format(time.RFC3339),
 		UpdatedAt: example.UpdatedAt.Format(time.RFC3339),
 	}
 }
-
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+)
+
+// multiPublisher publishes every event through several publishers in turn
+type multiPublisher struct {
+	publishers []external.EventPublisher
+}
+
+// NewMultiPublisher creates an event publisher that publishes to each of the
+// given publishers in order, stopping at the first failure. Combined with a
+// transaction, a failure of any of them rolls back the change.
+func NewMultiPublisher(publishers ...external.EventPublisher) external.EventPublisher {
+	return &multiPublisher{
+		publishers: publishers,
+	}
+}
+
+// Publish publishes the event through every publisher
+func (p *multiPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes every publisher and returns their combined errors
+func (p *multiPublisher) Close() error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,251 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"log"
+	"time"
+)
+
+// outboxPublisher stores events in the transactional outbox instead of
+// sending them to the broker
+type outboxPublisher struct {
+	outbox repositories.OutboxRepository
+	// broker, when set, receives events before they are stored
+	broker  external.EventPublisher
+	metrics *PublishMetrics
+}
+
+// NewOutboxPublisher creates an event publisher that writes events to the
+// outbox. Publishing inside a transaction stores the event atomically with
+// the entity change; the OutboxRelay delivers it afterwards.
+func NewOutboxPublisher(outbox repositories.OutboxRepository) external.EventPublisher {
+	return &outboxPublisher{
+		outbox: outbox,
+	}
+}
+
+// NewSyncOutboxPublisher creates an event publisher that publishes events to
+// the broker right away and stores them in the outbox as sent, for the
+// change feed. A broker failure fails the publish, and so rolls back the
+// change, with an error wrapping domain.ErrEventPublishFailed. An event may
+// still reach the broker for a change that then fails to commit.
+func NewSyncOutboxPublisher(
+	outbox repositories.OutboxRepository,
+	broker external.EventPublisher,
+	metrics *PublishMetrics,
+) external.EventPublisher {
+	if metrics == nil {
+		metrics = NewPublishMetrics()
+	}
+	return &outboxPublisher{
+		outbox:  outbox,
+		broker:  broker,
+		metrics: metrics,
+	}
+}
+
+// Publish stores the event in the outbox, publishing it to the broker first
+// if there is one
+func (p *outboxPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	message := &domain.OutboxMessage{
+		EventID:       event.ID,
+		AggregateID:   event.AggregateID,
+		EventType:     event.Type,
+		EventVersion:  event.Version,
+		Payload:       payload,
+		OccurredAt:    event.Timestamp,
+		NextAttemptAt: time.Now().UTC(),
+	}
+
+	if p.broker != nil {
+		if err := p.broker.Publish(ctx, event); err != nil {
+			p.metrics.failed.Add(1)
+			log.Printf("[EventPublisher] failed to publish %s event %s: %v", event.Type, event.ID, err)
+			return fmt.Errorf("%w %s: %w", domain.ErrEventPublishFailed, event.ID, err)
+		}
+		p.metrics.published.Add(1)
+		sentAt := time.Now().UTC()
+		message.SentAt = &sentAt
+	}
+
+	return p.outbox.Add(ctx, message)
+}
+
+// Close releases resources; the outbox holds no buffered state
+func (p *outboxPublisher) Close() error {
+	return nil
+}
+
+// OutboxRelayConfig configures the outbox relay
+type OutboxRelayConfig struct {
+	// PollInterval is how often the outbox is checked when it is idle
+	PollInterval time.Duration
+	// BatchSize is the maximum number of messages fetched at once
+	BatchSize int
+	// Lease is how long fetched messages stay claimed by this relay. Other
+	// relays sharing the outbox skip them meanwhile; it should comfortably
+	// exceed the time to publish a batch.
+	Lease time.Duration
+	// MaxBackoff caps the delay between retries of a failing message
+	MaxBackoff time.Duration
+	// DeadLetterAfter parks a message after this many failed attempts, so
+	// that it is no longer retried until it is replayed. Later messages for
+	// its aggregate wait for the replay; zero retries forever
+	DeadLetterAfter int
+	// Metrics counts publishing outcomes; optional
+	Metrics *PublishMetrics
+}
+
+// OutboxRelay publishes pending outbox messages through an event publisher.
+// Delivery is at-least-once: a message is marked sent only after a
+// successful publish, and a failing or dead-lettered message holds back later
+// messages for the same aggregate until it goes through. Several
+// relays may share an outbox; each batch is claimed so that only one relay
+// publishes a message at a time.
+type OutboxRelay struct {
+	outbox    repositories.OutboxRepository
+	publisher external.EventPublisher
+	cfg       OutboxRelayConfig
+}
+
+// NewOutboxRelay creates a new outbox relay
+func NewOutboxRelay(
+	outbox repositories.OutboxRepository,
+	publisher external.EventPublisher,
+	cfg OutboxRelayConfig,
+) *OutboxRelay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = time.Minute
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.Metrics == nil {
+		cfg.Metrics = NewPublishMetrics()
+	}
+
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		cfg:       cfg,
+	}
+}
+
+// Run relays messages until ctx is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[OutboxRelay] failed to relay messages: %v", err)
+		}
+
+		// Keep draining while batches come back full
+		if err == nil && n == r.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes one batch of due messages and returns how many
+// messages were fetched
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	messages, err := r.outbox.ClaimPending(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch pending messages: %w", err)
+	}
+
+	blocked := make(map[int64]bool)
+	for _, msg := range messages {
+		if ctx.Err() != nil {
+			return len(messages), ctx.Err()
+		}
+		if blocked[msg.AggregateID] {
+			continue
+		}
+
+		event := outboxEvent(msg)
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			r.cfg.Metrics.failed.Add(1)
+			attempt := msg.Attempts + 1
+			if r.cfg.DeadLetterAfter > 0 && attempt >= r.cfg.DeadLetterAfter {
+				r.cfg.Metrics.deadLettered.Add(1)
+				log.Printf("[OutboxRelay] dead-lettering message %d (%s event %s) after %d attempts: %v", msg.ID, event.Type, event.ID, attempt, err)
+				if err := r.outbox.MarkDeadLettered(ctx, msg.ID, err.Error()); err != nil {
+					return len(messages), fmt.Errorf("failed to dead-letter message: %w", err)
+				}
+				continue
+			}
+
+			blocked[msg.AggregateID] = true
+			next := time.Now().UTC().Add(r.backoff(attempt))
+			log.Printf("[OutboxRelay] failed to publish message %d (%s event %s, attempt %d): %v", msg.ID, event.Type, event.ID, attempt, err)
+			if err := r.outbox.MarkFailed(ctx, msg.ID, err.Error(), next); err != nil {
+				return len(messages), fmt.Errorf("failed to record publish failure: %w", err)
+			}
+			continue
+		}
+		r.cfg.Metrics.published.Add(1)
+
+		if err := r.outbox.MarkSent(ctx, msg.ID); err != nil {
+			// The message will be published again: at-least-once delivery
+			return len(messages), fmt.Errorf("failed to mark message %d sent: %w", msg.ID, err)
+		}
+	}
+
+	return len(messages), nil
+}
+
+// backoff returns the exponential retry delay for the given attempt
+func (r *OutboxRelay) backoff(attempt int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempt && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.MaxBackoff {
+		delay = r.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// outboxEvent rebuilds the domain event stored in an outbox message. Rows
+// written before events had ids get one derived from the outbox id, which is
+// just as stable across redeliveries.
+func outboxEvent(msg *domain.OutboxMessage) *domain.Event {
+	id := msg.EventID
+	if id == "" {
+		id = fmt.Sprintf("outbox-%d", msg.ID)
+	}
+	return &domain.Event{
+		ID:          id,
+		Type:        msg.EventType,
+		Version:     msg.EventVersion,
+		AggregateID: msg.AggregateID,
+		Payload:     json.RawMessage(msg.Payload),
+		Timestamp:   msg.OccurredAt,
+	}
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Page size limits for list requests
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// pageToken is the decoded form of the opaque page_token. It records the
+// sort keys of the last example returned and a fingerprint of the query, so a
+// token cannot be replayed against different filters or ordering.
+type pageToken struct {
+	Query     string    `json:"q"`
+	ID        int64     `json:"id"`
+	Name      string    `json:"n,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	UpdatedAt time.Time `json:"u"`
+}
+
+// buildExampleQuery converts a list request into a repository query
+func buildExampleQuery(req *dto.ListExamplesRequest) (repositories.ExampleQuery, error) {
+	query := repositories.ExampleQuery{
+		Filter: repositories.ExampleFilter{
+			Status:     req.Status,
+			NamePrefix: req.NamePrefix,
+		},
+		Limit: pageSize(req.PageSize),
+	}
+
+	var err error
+	if query.Filter.CreatedAfter, err = parseTime("created_after", req.CreatedAfter); err != nil {
+		return query, err
+	}
+	if query.Filter.CreatedBefore, err = parseTime("created_before", req.CreatedBefore); err != nil {
+		return query, err
+	}
+	if query.OrderBy, query.Desc, err = parseOrderBy(req.OrderBy); err != nil {
+		return query, err
+	}
+
+	if req.PageToken != "" {
+		cursor, err := decodePageToken(req.PageToken, queryFingerprint(query))
+		if err != nil {
+			return query, err
+		}
+		query.After = cursor
+	}
+
+	return query, nil
+}
+
+// pageSize applies the default and maximum page sizes
+func pageSize(requested int32) int {
+	switch {
+	case requested <= 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
+
+// parseTime parses an optional RFC 3339 timestamp. Times are kept in UTC,
+// which databases storing them as text need to compare them.
+func parseTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, invalidField(field, field+" must be an RFC 3339 timestamp")
+	}
+	return t.UTC(), nil
+}
+
+// parseOrderBy parses "field" or "field desc"/"field asc". Listings default
+// to ascending id order.
+func parseOrderBy(orderBy string) (repositories.ExampleSortField, bool, error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	if len(parts) == 0 {
+		return repositories.SortByID, false, nil
+	}
+
+	field := repositories.ExampleSortField(parts[0])
+	switch field {
+	case repositories.SortByID, repositories.SortByName, repositories.SortByCreatedAt, repositories.SortByUpdatedAt:
+	default:
+		return "", false, invalidField("order_by", "order_by must be one of: id, name, created_at, updated_at")
+	}
+
+	switch {
+	case len(parts) == 1:
+		return field, false, nil
+	case len(parts) == 2 && (parts[1] == "asc" || parts[1] == "desc"):
+		return field, parts[1] == "desc", nil
+	default:
+		return "", false, invalidField("order_by", `order_by must be a field optionally followed by "asc" or "desc"`)
+	}
+}
+
+// queryFingerprint identifies the filters and ordering of a query
+func queryFingerprint(query repositories.ExampleQuery) string {
+	f := query.Filter
+	key := fmt.Sprintf("%s|%s|%d|%d|%s|%t",
+		f.Status, f.NamePrefix, f.CreatedAfter.UnixNano(), f.CreatedBefore.UnixNano(), query.OrderBy, query.Desc)
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// encodePageToken builds the token resuming after the given example
+func encodePageToken(query repositories.ExampleQuery, last *domain.Example) string {
+	b, _ := json.Marshal(pageToken{
+		Query:     queryFingerprint(query),
+		ID:        last.ID,
+		Name:      last.Name,
+		CreatedAt: last.CreatedAt,
+		UpdatedAt: last.UpdatedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken validates a token against the current query
+func decodePageToken(token, fingerprint string) (*repositories.ExampleCursor, error) {
+	invalid := invalidField("page_token", "page_token is invalid or does not match the request")
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	var pt pageToken
+	if err := json.Unmarshal(b, &pt); err != nil || pt.Query != fingerprint {
+		return nil, invalid
+	}
+
+	return &repositories.ExampleCursor{
+		ID:        pt.ID,
+		Name:      pt.Name,
+		CreatedAt: pt.CreatedAt.UTC(),
+		UpdatedAt: pt.UpdatedAt.UTC(),
+	}, nil
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"context"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// WebhookDispatcherConfig configures the webhook dispatcher
+type WebhookDispatcherConfig struct {
+	// PollInterval is how often the queues are checked when they are idle
+	PollInterval time.Duration
+	// BatchSize is the maximum number of subscriptions served at once
+	BatchSize int
+	// Lease is how long fetched deliveries stay claimed by this dispatcher.
+	// Other dispatchers sharing the queues skip them meanwhile; it should
+	// comfortably exceed the send timeout.
+	Lease time.Duration
+	// MaxAttempts is how often a delivery is tried before it is marked failed
+	MaxAttempts int
+	// DisableAfter consecutive failed attempts disable a subscription
+	DisableAfter int
+	// InitialBackoff is the delay before the first retry; it doubles on each
+	// further attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// WebhookDispatcher sends queued webhook deliveries. Each subscription has
+// its own queue, delivered in order: a failing delivery is retried with
+// exponential backoff and holds back the rest of its queue, while other
+// subscriptions are served in parallel. A subscription whose endpoint keeps
+// failing is disabled. Several dispatchers may share the queues; each batch
+// is claimed so that only one dispatcher sends a delivery at a time.
+type WebhookDispatcher struct {
+	subscriptions repositories.WebhookSubscriptionRepository
+	deliveries    repositories.WebhookDeliveryRepository
+	sender        external.WebhookSender
+	cfg           WebhookDispatcherConfig
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(
+	subscriptions repositories.WebhookSubscriptionRepository,
+	deliveries repositories.WebhookDeliveryRepository,
+	sender external.WebhookSender,
+	cfg WebhookDispatcherConfig,
+) *WebhookDispatcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = time.Minute
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.DisableAfter <= 0 {
+		cfg.DisableAfter = 20
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = 5 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+
+	return &WebhookDispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		cfg:           cfg,
+	}
+}
+
+// Run sends deliveries until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.DeliverDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[WebhookDispatcher] failed to deliver webhooks: %v", err)
+		}
+
+		// Keep draining while batches come back full
+		if err == nil && n == d.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue attempts the due delivery at the head of each subscription's
+// queue and returns how many deliveries were attempted
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.deliveries.ClaimDue(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch due deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(deliveries))
+	for i, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return len(deliveries), err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt at a delivery and records its outcome on the
+// delivery and its subscription
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	subscription, err := d.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	if subscription == nil || !subscription.Active {
+		// Deleted or disabled while the delivery was queued
+		return nil
+	}
+
+	status, sendErr := d.sender.Send(ctx, subscription, delivery)
+	if sendErr != nil && ctx.Err() != nil {
+		// Shutting down; the attempt is retried once the claim runs out
+		return ctx.Err()
+	}
+
+	// Counters are updated in place, never saved from the copies read
+	// before the send
+	if sendErr == nil {
+		if err := d.deliveries.MarkSucceeded(ctx, delivery.ID, status); err != nil {
+			return fmt.Errorf("failed to record delivery %d: %w", delivery.ID, err)
+		}
+		if err := d.subscriptions.RecordSuccess(ctx, subscription.ID); err != nil {
+			return fmt.Errorf("failed to update webhook subscription %d: %w", subscription.ID, err)
+		}
+		return nil
+	}
+
+	attempt := delivery.Attempts + 1
+	log.Printf("[WebhookDispatcher] delivery %d of %s to subscription %d failed (attempt %d): %v",
+		delivery.ID, delivery.EventType, subscription.ID, attempt, sendErr)
+	if attempt >= d.cfg.MaxAttempts {
+		err = d.deliveries.MarkFailed(ctx, delivery.ID, status, sendErr.Error())
+	} else {
+		next := time.Now().UTC().Add(d.backoff(attempt))
+		err = d.deliveries.MarkRetrying(ctx, delivery.ID, status, sendErr.Error(), next)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record delivery %d: %w", delivery.ID, err)
+	}
+
+	disabled, err := d.subscriptions.RecordFailure(ctx, subscription.ID, d.cfg.DisableAfter)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription %d: %w", subscription.ID, err)
+	}
+	if disabled {
+		log.Printf("[WebhookDispatcher] disabled subscription %d after %d consecutive failures",
+			subscription.ID, d.cfg.DisableAfter)
+	}
+	return nil
+}
+
+// backoff returns the exponential retry delay after the given attempt
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.InitialBackoff
+	for i := 1; i < attempt && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxBackoff {
+		delay = d.cfg.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"example-service/internal/ports/repositories"
+	"example-service/internal/ports/services"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Delivery log page size
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+// webhookEventTypes are the event types a subscription can filter on
+var webhookEventTypes = map[string]bool{
+	domain.EventTypeExampleCreated: true,
+	domain.EventTypeExampleUpdated: true,
+	domain.EventTypeExampleDeleted: true,
+}
+
+// WebhookService implements the webhook service interface
+type WebhookService struct {
+	subscriptions repositories.WebhookSubscriptionRepository
+	deliveries    repositories.WebhookDeliveryRepository
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	subscriptions repositories.WebhookSubscriptionRepository,
+	deliveries repositories.WebhookDeliveryRepository,
+) services.WebhookService {
+	return &WebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+	}
+}
+
+// CreateWebhook subscribes an endpoint to example events
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, invalidField("url", "url must be an absolute http or https URL")
+	}
+	for _, eventType := range req.EventTypes {
+		if !webhookEventTypes[eventType] {
+			return nil, invalidField("event_types", fmt.Sprintf("unknown event type %q", eventType))
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := &domain.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     secret,
+		Active:     true,
+	}
+	if err := s.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	// The secret is only ever returned here
+	resp := webhookToDTO(subscription)
+	resp.Secret = secret
+	return resp, nil
+}
+
+// GetWebhook retrieves a subscription by ID
+func (s *WebhookService) GetWebhook(ctx context.Context, id int64) (*dto.WebhookResponse, error) {
+	subscription, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return webhookToDTO(subscription), nil
+}
+
+// ListWebhooks retrieves all subscriptions
+func (s *WebhookService) ListWebhooks(ctx context.Context) (*dto.ListWebhooksResponse, error) {
+	subscriptions, err := s.subscriptions.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	resp := &dto.ListWebhooksResponse{Webhooks: make([]*dto.WebhookResponse, len(subscriptions))}
+	for i, subscription := range subscriptions {
+		resp.Webhooks[i] = webhookToDTO(subscription)
+	}
+	return resp, nil
+}
+
+// DeleteWebhook removes a subscription and its delivery log
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries retrieves the delivery log of a subscription, newest first.
+// A limit of zero returns the default page size.
+func (s *WebhookService) ListDeliveries(ctx context.Context, id int64, limit int) (*dto.ListWebhookDeliveriesResponse, error) {
+	if limit < 0 || limit > maxDeliveryLimit {
+		return nil, invalidField("limit", fmt.Sprintf("limit must be between 0 and %d", maxDeliveryLimit))
+	}
+	if limit == 0 {
+		limit = defaultDeliveryLimit
+	}
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.deliveries.ListBySubscription(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	resp := &dto.ListWebhookDeliveriesResponse{Deliveries: make([]*dto.WebhookDeliveryResponse, len(deliveries))}
+	for i, delivery := range deliveries {
+		resp.Deliveries[i] = deliveryToDTO(delivery)
+	}
+	return resp, nil
+}
+
+// find loads a subscription, returning domain.ErrWebhookNotFound if it does not exist
+func (s *WebhookService) find(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	if subscription == nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return subscription, nil
+}
+
+// webhookPublisher queues a delivery of each event for every active
+// subscription that accepts it
+type webhookPublisher struct {
+	subscriptions repositories.WebhookSubscriptionRepository
+	deliveries    repositories.WebhookDeliveryRepository
+}
+
+// NewWebhookPublisher creates an event publisher that enqueues webhook
+// deliveries. Like the outbox publisher it only writes to the database, so
+// publishing inside a transaction queues the deliveries atomically with the
+// entity change; the WebhookDispatcher sends them afterwards.
+func NewWebhookPublisher(
+	subscriptions repositories.WebhookSubscriptionRepository,
+	deliveries repositories.WebhookDeliveryRepository,
+) external.EventPublisher {
+	return &webhookPublisher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+	}
+}
+
+// Publish enqueues a delivery per interested subscription
+func (p *webhookPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	subscriptions, err := p.subscriptions.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	var payload []byte
+	now := time.Now().UTC()
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event.Payload); err != nil {
+				return fmt.Errorf("failed to encode event payload: %w", err)
+			}
+		}
+
+		err := p.deliveries.Enqueue(ctx, &domain.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			EventVersion:   event.Version,
+			AggregateID:    event.AggregateID,
+			Payload:        payload,
+			OccurredAt:     event.Timestamp,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close releases resources; deliveries are stored in the database
+func (p *webhookPublisher) Close() error {
+	return nil
+}
+
+// newWebhookSecret generates a random signing secret
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// webhookToDTO converts a subscription to a DTO without its secret
+func webhookToDTO(subscription *domain.WebhookSubscription) *dto.WebhookResponse {
+	resp := &dto.WebhookResponse{
+		ID:                  subscription.ID,
+		URL:                 subscription.URL,
+		EventTypes:          subscription.EventTypes,
+		Active:              subscription.Active,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		CreatedAt:           subscription.CreatedAt.Format(time.RFC3339),
+	}
+	if resp.EventTypes == nil {
+		resp.EventTypes = []string{}
+	}
+	if subscription.DisabledAt != nil {
+		resp.DisabledAt = subscription.DisabledAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// deliveryToDTO converts a delivery to a delivery log entry
+func deliveryToDTO(delivery *domain.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	resp := &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		AggregateID:    delivery.AggregateID,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == domain.DeliveryPending {
+		resp.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		resp.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	return resp
+}
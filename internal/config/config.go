@@ -3,11 +3,49 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// HTTP serving modes
+const (
+	// HTTPModeGateway serves REST through grpc-gateway backed by the gRPC handler
+	HTTPModeGateway = "gateway"
+	// HTTPModeMux serves REST through the hand-written gorilla/mux handler
+	HTTPModeMux = "mux"
+)
+
+// MemoryDatabaseURL selects in-memory storage instead of PostgreSQL. Data is
+// lost when the service stops. Besides it, DATABASE_URL takes a PostgreSQL
+// connection URL or sqlite://<path> for a SQLite database file.
+const MemoryDatabaseURL = "memory://"
+
+// Event brokers
+const (
+	// EventBrokerKafka publishes and consumes events through Kafka
+	EventBrokerKafka = "kafka"
+	// EventBrokerRedis publishes and consumes events through Redis Streams
+	EventBrokerRedis = "redis"
+	// EventBrokerNone only delivers events to in-process subscribers
+	EventBrokerNone = "none"
+)
+
+// Event publish policies
+const (
+	// PublishPolicyFail publishes events to the broker while handling the
+	// request, failing it when the broker is unavailable
+	PublishPolicyFail = "fail"
+	// PublishPolicyRetry relays events from the outbox, retrying failures
+	// in the background until they go through
+	PublishPolicyRetry = "retry"
+	// PublishPolicyDeadLetter relays events like PublishPolicyRetry but
+	// parks an event after OutboxMaxAttempts failed attempts; later events
+	// for its aggregate wait until it is replayed
+	PublishPolicyDeadLetter = "dead_letter"
+)
+
 // Config holds all application configuration
 type Config struct {
 	DatabaseURL        string
@@ -18,6 +56,108 @@ type Config struct {
 	RefreshTokenExpiry time.Duration
 	GRPCPort           string
 	HTTPPort           string
+	HTTPMode           string
+	ShutdownTimeout    time.Duration
+
+	// CaseInsensitiveNames makes example names unique regardless of case
+	CaseInsensitiveNames bool
+	// MigrateOnStart applies pending migrations when the server starts
+	MigrateOnStart bool
+
+	// Outbox relay settings
+	OutboxPollInterval time.Duration
+	OutboxBatchSize    int
+	// OutboxMaxAttempts is how often an event is tried before it is parked
+	// under PublishPolicyDeadLetter
+	OutboxMaxAttempts int
+
+	// PublishPolicy decides what happens when an event cannot be published:
+	// PublishPolicyFail, PublishPolicyRetry or PublishPolicyDeadLetter
+	PublishPolicy string
+
+	// ChangeFeedPollInterval is how often the change feed behind
+	// WatchExamples reads new events from the outbox
+	ChangeFeedPollInterval time.Duration
+	// StreamHeartbeatInterval is how often idle Server-Sent Events streams
+	// get a heartbeat comment
+	StreamHeartbeatInterval time.Duration
+
+	// Webhook delivery settings
+	WebhookPollInterval time.Duration
+	WebhookTimeout      time.Duration
+	// WebhookMaxAttempts is how often a delivery is tried before it fails
+	WebhookMaxAttempts int
+	// WebhookDisableAfter consecutive failures disable a subscription
+	WebhookDisableAfter int
+
+	// Events are published as CloudEvents with this source, and their
+	// dataschema is "<EventSchemaBase>:<type>:v<version>"
+	EventSource     string
+	EventSchemaBase string
+	// EventFormat encodes event data as "json" or "protobuf"
+	EventFormat string
+
+	// EventBroker selects the broker events are published to and consumed
+	// from: EventBrokerKafka, EventBrokerRedis or EventBrokerNone
+	EventBroker  string
+	Kafka        KafkaConfig
+	RedisStreams RedisStreamConfig
+}
+
+// KafkaConfig holds the Kafka producer settings
+type KafkaConfig struct {
+	Brokers []string
+	// TopicPrefix is prepended to the kebab-cased event type, so
+	// ExampleCreated goes to "<prefix>example-created" unless Topics names a
+	// topic for it
+	TopicPrefix string
+	Topics      map[string]string
+	// Acks is "all", "leader" or "none"
+	Acks string
+	// Compression is "none", "gzip", "snappy", "lz4" or "zstd"
+	Compression string
+	Idempotent  bool
+	ClientID    string
+	// ContentMode is the CloudEvents content mode, "binary" or "structured"
+	ContentMode string
+
+	Consumer KafkaConsumerConfig
+}
+
+// RedisStreamConfig holds the Redis Streams settings
+type RedisStreamConfig struct {
+	// Stream receives every published event
+	Stream string
+	// MaxLen bounds the stream length; older entries are trimmed
+	MaxLen int64
+
+	// ConsumerStreams to consume; the consumer is disabled when empty
+	ConsumerStreams []string
+	ConsumerGroup   string
+	// ConsumerName identifies this instance within the group
+	ConsumerName string
+	// DeadLetterStream receives events that could not be processed
+	DeadLetterStream string
+	// MaxAttempts is how often an event is delivered before it is dead-lettered
+	MaxAttempts int
+	// ClaimMinIdle is how long an entry stays pending before another
+	// delivery is attempted
+	ClaimMinIdle time.Duration
+}
+
+// KafkaConsumerConfig holds the settings for consuming events from other
+// services
+type KafkaConsumerConfig struct {
+	// Topics to consume; the consumer is disabled when empty
+	Topics  []string
+	GroupID string
+	// DeadLetterTopic receives events that could not be processed
+	DeadLetterTopic string
+	// MaxAttempts is how often an event is tried before it is dead-lettered
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry; it doubles on each
+	// further attempt
+	RetryBackoff time.Duration
 }
 
 // Load loads configuration from environment variables
@@ -27,6 +167,22 @@ func Load() (*Config, error) {
 
 	accessExpiry, _ := strconv.Atoi(getEnv("ACCESS_TOKEN_EXPIRY", "900"))      // 15 minutes
 	refreshExpiry, _ := strconv.Atoi(getEnv("REFRESH_TOKEN_EXPIRY", "604800")) // 7 days
+	shutdownTimeout, _ := strconv.Atoi(getEnv("SHUTDOWN_TIMEOUT", "30"))       // 30 seconds
+	outboxPollInterval, _ := strconv.Atoi(getEnv("OUTBOX_POLL_INTERVAL_MS", "1000"))
+	outboxBatchSize, _ := strconv.Atoi(getEnv("OUTBOX_BATCH_SIZE", "100"))
+	outboxMaxAttempts, _ := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "10"))
+	changeFeedPollInterval, _ := strconv.Atoi(getEnv("CHANGE_FEED_POLL_INTERVAL_MS", "200"))
+	streamHeartbeatInterval, _ := strconv.Atoi(getEnv("SSE_HEARTBEAT_INTERVAL_MS", "15000"))
+	webhookPollInterval, _ := strconv.Atoi(getEnv("WEBHOOK_POLL_INTERVAL_MS", "1000"))
+	webhookTimeout, _ := strconv.Atoi(getEnv("WEBHOOK_TIMEOUT_MS", "10000"))
+	webhookMaxAttempts, _ := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"))
+	webhookDisableAfter, _ := strconv.Atoi(getEnv("WEBHOOK_DISABLE_AFTER", "20"))
+	consumerMaxAttempts, _ := strconv.Atoi(getEnv("KAFKA_CONSUMER_MAX_ATTEMPTS", "5"))
+	consumerRetryBackoff, _ := strconv.Atoi(getEnv("KAFKA_CONSUMER_RETRY_BACKOFF_MS", "500"))
+	redisStreamMaxLen, _ := strconv.ParseInt(getEnv("REDIS_STREAM_MAXLEN", "100000"), 10, 64)
+	redisMaxAttempts, _ := strconv.Atoi(getEnv("REDIS_CONSUMER_MAX_ATTEMPTS", "5"))
+	redisClaimMinIdle, _ := strconv.Atoi(getEnv("REDIS_CLAIM_MIN_IDLE_MS", "30000"))
+	hostname, _ := os.Hostname()
 
 	accessTokenExpiry := time.Duration(accessExpiry) * time.Second
 	refreshTokenExpiry := time.Duration(refreshExpiry) * time.Second
@@ -37,9 +193,64 @@ func Load() (*Config, error) {
 		RedisPassword:      getEnv("REDIS_PASSWORD", ""),
 		GRPCPort:           getEnv("GRPC_PORT", "50051"),
 		HTTPPort:           getEnv("HTTP_PORT", "8081"),
+		HTTPMode:           getEnv("HTTP_MODE", HTTPModeGateway),
 		JWTSecret:          getEnv("JWT_SECRET", "your-secret-key"),
 		AccessTokenExpiry:  accessTokenExpiry,
 		RefreshTokenExpiry: refreshTokenExpiry,
+		ShutdownTimeout:    time.Duration(shutdownTimeout) * time.Second,
+
+		CaseInsensitiveNames: getEnv("CASE_INSENSITIVE_NAMES", "false") == "true",
+		MigrateOnStart:       getEnv("MIGRATE_ON_START", "true") == "true",
+
+		OutboxPollInterval: time.Duration(outboxPollInterval) * time.Millisecond,
+		OutboxBatchSize:    outboxBatchSize,
+		OutboxMaxAttempts:  outboxMaxAttempts,
+
+		PublishPolicy: getEnv("EVENT_PUBLISH_POLICY", PublishPolicyRetry),
+
+		ChangeFeedPollInterval:  time.Duration(changeFeedPollInterval) * time.Millisecond,
+		StreamHeartbeatInterval: time.Duration(streamHeartbeatInterval) * time.Millisecond,
+
+		WebhookPollInterval: time.Duration(webhookPollInterval) * time.Millisecond,
+		WebhookTimeout:      time.Duration(webhookTimeout) * time.Millisecond,
+		WebhookMaxAttempts:  webhookMaxAttempts,
+		WebhookDisableAfter: webhookDisableAfter,
+
+		EventSource:     getEnv("EVENT_SOURCE", "example-service"),
+		EventSchemaBase: getEnv("EVENT_SCHEMA_BASE", "urn:example-service:events"),
+		EventFormat:     getEnv("EVENT_FORMAT", "json"),
+
+		EventBroker: getEnv("EVENT_BROKER", EventBrokerKafka),
+
+		Kafka: KafkaConfig{
+			Brokers:     splitList(getEnv("KAFKA_BROKERS", "localhost:9092")),
+			TopicPrefix: getEnv("KAFKA_TOPIC_PREFIX", "example-service."),
+			Topics:      parseTopics(getEnv("KAFKA_TOPICS", "")),
+			Acks:        getEnv("KAFKA_ACKS", "all"),
+			Compression: getEnv("KAFKA_COMPRESSION", "snappy"),
+			Idempotent:  getEnv("KAFKA_IDEMPOTENT", "true") == "true",
+			ClientID:    getEnv("KAFKA_CLIENT_ID", "example-service"),
+			ContentMode: getEnv("KAFKA_CONTENT_MODE", "binary"),
+
+			Consumer: KafkaConsumerConfig{
+				Topics:          splitList(getEnv("KAFKA_CONSUMER_TOPICS", "")),
+				GroupID:         getEnv("KAFKA_CONSUMER_GROUP", "example-service"),
+				DeadLetterTopic: getEnv("KAFKA_DEAD_LETTER_TOPIC", "example-service.dlt"),
+				MaxAttempts:     consumerMaxAttempts,
+				RetryBackoff:    time.Duration(consumerRetryBackoff) * time.Millisecond,
+			},
+		},
+
+		RedisStreams: RedisStreamConfig{
+			Stream:           getEnv("REDIS_STREAM", "example-service.events"),
+			MaxLen:           redisStreamMaxLen,
+			ConsumerStreams:  splitList(getEnv("REDIS_CONSUMER_STREAMS", "")),
+			ConsumerGroup:    getEnv("REDIS_CONSUMER_GROUP", "example-service"),
+			ConsumerName:     getEnv("REDIS_CONSUMER_NAME", hostname),
+			DeadLetterStream: getEnv("REDIS_DEAD_LETTER_STREAM", "example-service.dlt"),
+			MaxAttempts:      redisMaxAttempts,
+			ClaimMinIdle:     time.Duration(redisClaimMinIdle) * time.Millisecond,
+		},
 	}, nil
 }
 
@@ -50,3 +261,24 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTopics parses "EventType=topic,..." pairs
+func parseTopics(value string) map[string]string {
+	topics := make(map[string]string)
+	for _, pair := range splitList(value) {
+		if eventType, topic, ok := strings.Cut(pair, "="); ok {
+			topics[strings.TrimSpace(eventType)] = strings.TrimSpace(topic)
+		}
+	}
+	return topics
+}
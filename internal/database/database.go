@@ -3,25 +3,99 @@ package database
 import (
 	"fmt"
 	"log"
+	"net/url"
+	"strings"
 	"time"
 
-	"example-service/internal/domain"
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-// InitGORM initializes GORM database connection
+// sqlitePrefix marks a connection URL as the path of a SQLite database file
+const sqlitePrefix = "sqlite://"
+
+// sqliteOptions are the connection settings for SQLite databases. WAL lets
+// readers run alongside the writer, writers wait for each other instead of
+// failing, and transactions take the write lock up front so that they
+// cannot deadlock upgrading it. LIKE is case sensitive, as the repositories
+// expect from PostgreSQL. The driver already stores times in SQLite's
+// sortable text format.
+var sqliteOptions = url.Values{
+	"_pragma": {
+		"journal_mode(WAL)",
+		"busy_timeout(5000)",
+		"foreign_keys(1)",
+		"synchronous(NORMAL)",
+		"case_sensitive_like(1)",
+	},
+	"_txlock": {"immediate"},
+}
+
+// dialector returns the GORM dialector for a connection URL: a SQLite
+// database for sqlite://<path>, PostgreSQL otherwise
+func dialector(connectionURL string) (gorm.Dialector, error) {
+	if !strings.HasPrefix(connectionURL, sqlitePrefix) {
+		return postgres.Open(connectionURL), nil
+	}
+
+	path, query, _ := strings.Cut(strings.TrimPrefix(connectionURL, sqlitePrefix), "?")
+	if path == "" {
+		return nil, fmt.Errorf("SQLite database URL %q has no file path", connectionURL)
+	}
+	options, err := sqliteDSNOptions(query)
+	if err != nil {
+		return nil, err
+	}
+	return sqlite.Open(path + "?" + options), nil
+}
+
+// sqliteDSNOptions merges the options of a SQLite URL into the defaults.
+// Pragmas in the URL run after the default ones, so they override them;
+// any other option replaces the default. _time_format is rejected, since
+// the driver ignores _txlock when it is set.
+func sqliteDSNOptions(query string) (string, error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("invalid SQLite database URL options: %w", err)
+	}
+	if values.Has("_time_format") {
+		return "", fmt.Errorf("the SQLite option _time_format is not supported")
+	}
+
+	options := url.Values{}
+	for key, defaults := range sqliteOptions {
+		options[key] = append([]string(nil), defaults...)
+	}
+	for key, v := range values {
+		if key == "_pragma" {
+			options[key] = append(options[key], v...)
+			continue
+		}
+		options[key] = v
+	}
+	return options.Encode(), nil
+}
+
+// InitGORM initializes GORM database connection. The URL is either a
+// PostgreSQL connection URL or sqlite://<path> for a SQLite database file.
 func InitGORM(connectionURL string) (*gorm.DB, error) {
 	if connectionURL == "" {
 		return nil, fmt.Errorf("database connection URL is required")
 	}
+	dialect, err := dialector(connectionURL)
+	if err != nil {
+		return nil, err
+	}
 
-	db, err := gorm.Open(postgres.Open(connectionURL), &gorm.Config{
+	db, err := gorm.Open(dialect, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
+		// Translate driver errors such as unique violations into gorm errors
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -47,18 +121,50 @@ func InitGORM(connectionURL string) (*gorm.DB, error) {
 	return db, nil
 }
 
-// AutoMigrate runs GORM auto migrations
-func AutoMigrate(db *gorm.DB) error {
-	// Auto migrate all models
-	err := db.AutoMigrate(
-		&domain.Example{},
-		// Add more domain entities here as needed
-	)
-	if err != nil {
-		return fmt.Errorf("failed to auto migrate: %w", err)
+// Unique indexes on example names
+const (
+	exampleNameIndex      = "idx_examples_name"
+	exampleNameLowerIndex = "idx_examples_name_lower"
+)
+
+// exampleNameIndexFor returns the unique name index for a case sensitivity
+// mode and the index of the other mode
+func exampleNameIndexFor(caseInsensitive bool) (index, other string) {
+	if caseInsensitive {
+		return exampleNameLowerIndex, exampleNameIndex
+	}
+	return exampleNameIndex, exampleNameLowerIndex
+}
+
+// migrateExampleNameIndex creates the unique index enforcing example name
+// uniqueness, dropping the index for the other case sensitivity mode. It is
+// not a versioned migration because the mode is configured per deployment.
+func migrateExampleNameIndex(db *gorm.DB, caseInsensitive bool) error {
+	index, drop := exampleNameIndexFor(caseInsensitive)
+	create := "CREATE UNIQUE INDEX IF NOT EXISTS " + index + " ON examples (name)"
+	if caseInsensitive {
+		create = "CREATE UNIQUE INDEX IF NOT EXISTS " + index + " ON examples (lower(name))"
 	}
 
-	log.Println("Database migrations completed successfully")
+	if err := db.Exec(create).Error; err != nil {
+		return fmt.Errorf("failed to create unique name index (are there duplicate example names?): %w", err)
+	}
+	if err := db.Exec("DROP INDEX IF EXISTS " + drop).Error; err != nil {
+		return fmt.Errorf("failed to drop name index %s: %w", drop, err)
+	}
 	return nil
 }
 
+// Close closes the underlying connection pool
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	log.Println("Database connection closed")
+	return nil
+}
@@ -0,0 +1,446 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"example-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// Database dialects, as named by their GORM dialector. Each has its own
+// directory of migrations.
+const (
+	dialectPostgres = "postgres"
+	dialectSQLite   = "sqlite"
+)
+
+// migrationLockID is the Postgres advisory lock key held while migrating, so
+// that replicas starting together migrate one at a time
+const migrationLockID = 7_246_001_305
+
+// migrationFileName matches <version>_<name>.<up|down>.sql
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// models are the GORM models whose tables the migrations create
+var models = []interface{}{
+	&domain.Example{},
+	&domain.OutboxMessage{},
+	&domain.ProcessedEvent{},
+	&domain.WebhookSubscription{},
+	&domain.WebhookDelivery{},
+}
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// String formats the migration as <version>_<name>
+func (m Migration) String() string {
+	if m.Name == "" {
+		return strconv.FormatInt(m.Version, 10)
+	}
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// MigrationStatus is a migration and when it was applied. Migrations
+// applied to the database but unknown to this build have no SQL.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// MigrateOptions configures schema migrations
+type MigrateOptions struct {
+	// CaseInsensitiveNames makes example names unique regardless of case
+	CaseInsensitiveNames bool
+}
+
+// ParseMigrations reads the migrations in the root of fsys, ordered by
+// version. Every version needs an up and a down file.
+func ParseMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migration %s needs non-empty up and down files", m)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator applies and rolls back the embedded migrations. Every migration
+// runs in a transaction together with its schema_migrations row. On
+// PostgreSQL Up, Down and To hold an advisory lock and each migration
+// commits on its own; on SQLite each of them is a single transaction, which
+// also keeps other migrators out.
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+	opts       MigrateOptions
+}
+
+// Migrations returns the migrations embedded in the binary for a dialect,
+// "postgres" or "sqlite"
+func Migrations(dialect string) ([]Migration, error) {
+	if dialect != dialectPostgres && dialect != dialectSQLite {
+		return nil, fmt.Errorf("no migrations for %s databases", dialect)
+	}
+	sub, err := fs.Sub(migrationFiles, "migrations/"+dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+	return ParseMigrations(sub)
+}
+
+// NewMigrator creates a migrator for the embedded migrations of the
+// database's dialect
+func NewMigrator(db *gorm.DB, opts MigrateOptions) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	migrations, err := Migrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+		opts:       opts,
+	}, nil
+}
+
+// Up applies every pending migration, then makes sure the example name
+// index matches the configured case sensitivity
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := apply(conn, migration); err != nil {
+				return err
+			}
+		}
+		if latest := m.latest(); latest > 0 {
+			for version := range applied {
+				if version > latest {
+					log.Printf("Database has migration %d, which is newer than this build (%d)", version, latest)
+				}
+			}
+		}
+		return migrateExampleNameIndex(conn, m.opts.CaseInsensitiveNames)
+	})
+}
+
+// Down rolls back the most recently applied migration
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(ctx, func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		var current int64
+		for version := range applied {
+			current = max(current, version)
+		}
+		if current == 0 {
+			log.Println("No migrations to roll back")
+			return nil
+		}
+		migration, err := m.find(current)
+		if err != nil {
+			return err
+		}
+		return revert(conn, migration)
+	})
+}
+
+// To applies or rolls back migrations until exactly the migrations up to
+// version are applied, then makes sure the example name index matches the
+// configured case sensitivity. Version 0 rolls back every migration.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 {
+		if _, err := m.find(version); err != nil {
+			return err
+		}
+	}
+
+	return m.locked(ctx, func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		// Roll back newer migrations, newest first
+		var newer []int64
+		for v := range applied {
+			if v > version {
+				newer = append(newer, v)
+			}
+		}
+		sort.Slice(newer, func(i, j int) bool { return newer[i] > newer[j] })
+		for _, v := range newer {
+			migration, err := m.find(v)
+			if err != nil {
+				return err
+			}
+			if err := revert(conn, migration); err != nil {
+				return err
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := apply(conn, migration); err != nil {
+				return err
+			}
+		}
+		if version == 0 {
+			return nil
+		}
+		return migrateExampleNameIndex(conn, m.opts.CaseInsensitiveNames)
+	})
+}
+
+// Status lists every known or applied migration, ordered by version. It only
+// reads: it takes no lock and creates nothing, and on a database without a
+// schema_migrations table every migration is pending.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn := m.db.WithContext(ctx)
+	applied := make(map[int64]time.Time)
+	if conn.Migrator().HasTable("schema_migrations") {
+		var err error
+		if applied, err = appliedVersions(conn); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if at, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &at
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, at := range applied {
+		statuses = append(statuses, MigrationStatus{Migration: Migration{Version: version}, AppliedAt: &at})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// latest returns the newest migration version known to this build
+func (m *Migrator) latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// find returns the migration with the given version
+func (m *Migrator) find(version int64) (Migration, error) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, nil
+		}
+	}
+	return Migration{}, fmt.Errorf("migration %d is unknown to this build", version)
+}
+
+// locked runs fn on a single connection holding the migration lock, after
+// creating the schema_migrations table if needed. On SQLite the connection
+// is a transaction, which holds the database's write lock.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	if m.dialect == dialectSQLite {
+		return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+				version    INTEGER PRIMARY KEY,
+				name       TEXT NOT NULL,
+				applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)`).Error
+			if err != nil {
+				return fmt.Errorf("failed to create schema_migrations table: %w", err)
+			}
+			return fn(tx)
+		})
+	}
+
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			// Unlock even when ctx was cancelled
+			if err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", migrationLockID).Error; err != nil {
+				log.Printf("failed to release migration lock: %v", err)
+			}
+		}()
+
+		err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`).Error
+		if err != nil {
+			return fmt.Errorf("failed to create schema_migrations table: %w", err)
+		}
+		return fn(conn)
+	})
+}
+
+// appliedVersions returns the applied migration versions and when they were applied
+func appliedVersions(conn *gorm.DB) (map[int64]time.Time, error) {
+	var rows []struct {
+		Version   int64
+		AppliedAt time.Time
+	}
+	if err := conn.Raw("SELECT version, applied_at FROM schema_migrations").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+// apply runs a migration's up SQL and records it
+func apply(conn *gorm.DB, migration Migration) error {
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %s: %w", migration, err)
+	}
+	log.Printf("Applied migration %s", migration)
+	return nil
+}
+
+// revert runs a migration's down SQL and removes its record
+func revert(conn *gorm.DB, migration Migration) error {
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration %s: %w", migration, err)
+	}
+	log.Printf("Rolled back migration %s", migration)
+	return nil
+}
+
+// CheckSchema verifies that the table and columns of every GORM model and
+// the example name index for the configured case sensitivity exist,
+// reporting everything that is missing
+func CheckSchema(db *gorm.DB, opts MigrateOptions) error {
+	var problems []string
+	migrator := db.Migrator()
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+		if !migrator.HasTable(table) {
+			problems = append(problems, "missing table "+table)
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(table)
+		if err != nil {
+			return fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		columns := make(map[string]bool, len(columnTypes))
+		for _, ct := range columnTypes {
+			columns[ct.Name()] = true
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !columns[field.DBName] {
+				problems = append(problems, fmt.Sprintf("missing column %s.%s", table, field.DBName))
+			}
+		}
+	}
+
+	if index, _ := exampleNameIndexFor(opts.CaseInsensitiveNames); migrator.HasTable("examples") && !migrator.HasIndex("examples", index) {
+		problems = append(problems, "missing unique index "+index)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database schema does not match the models, are migrations applied? %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+-- The schema previously created by GORM AutoMigrate. Every statement is
+-- conditional so databases created that way are adopted as they are.
+
+CREATE TABLE IF NOT EXISTS examples (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    status     VARCHAR(50) DEFAULT 'active',
+    version    BIGINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_examples_status ON examples (status);
+CREATE INDEX IF NOT EXISTS idx_examples_created_at ON examples (created_at);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id               BIGSERIAL PRIMARY KEY,
+    event_id         VARCHAR(36) NOT NULL DEFAULT '',
+    aggregate_id     BIGINT NOT NULL,
+    event_type       VARCHAR(100) NOT NULL,
+    event_version    BIGINT NOT NULL DEFAULT 1,
+    payload          BYTEA NOT NULL,
+    occurred_at      TIMESTAMPTZ NOT NULL,
+    attempts         BIGINT NOT NULL DEFAULT 0,
+    last_error       TEXT,
+    next_attempt_at  TIMESTAMPTZ NOT NULL,
+    sent_at          TIMESTAMPTZ,
+    dead_lettered_at TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate_id ON outbox (aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt_at ON outbox (next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_sent_at ON outbox (sent_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_lettered_at ON outbox (dead_lettered_at);
+
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer     VARCHAR(100) NOT NULL,
+    event_id     VARCHAR(255) NOT NULL,
+    event_type   VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMPTZ,
+    PRIMARY KEY (consumer, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events (processed_at);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                   BIGSERIAL PRIMARY KEY,
+    url                  TEXT NOT NULL,
+    event_types          TEXT,
+    secret               VARCHAR(255) NOT NULL,
+    active               BOOLEAN NOT NULL DEFAULT true,
+    consecutive_failures BIGINT NOT NULL DEFAULT 0,
+    disabled_at          TIMESTAMPTZ,
+    created_at           TIMESTAMPTZ,
+    updated_at           TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL,
+    event_id        VARCHAR(255) NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    event_version   BIGINT NOT NULL DEFAULT 1,
+    aggregate_id    BIGINT NOT NULL,
+    payload         BYTEA NOT NULL,
+    occurred_at     TIMESTAMPTZ NOT NULL,
+    status          VARCHAR(20) NOT NULL,
+    attempts        BIGINT NOT NULL DEFAULT 0,
+    response_status BIGINT,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivered_at    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_examples_account_id;
+ALTER TABLE examples DROP COLUMN IF EXISTS account_id;
@@ -0,0 +1,5 @@
+-- The account that owns each example. Examples of a closed account are
+-- deactivated; an empty account id means the example has no owner.
+
+ALTER TABLE examples ADD COLUMN IF NOT EXISTS account_id VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_examples_account_id ON examples (account_id);
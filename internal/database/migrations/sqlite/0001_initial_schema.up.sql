@@ -0,0 +1,76 @@
+-- The initial schema on SQLite. Timestamps are DATETIME so that the driver
+-- reads them back as times, and AUTOINCREMENT keeps ids from being reused
+-- like the PostgreSQL sequences do.
+
+CREATE TABLE IF NOT EXISTS examples (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       TEXT NOT NULL,
+    status     TEXT DEFAULT 'active',
+    version    INTEGER NOT NULL DEFAULT 1,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_examples_status ON examples (status);
+CREATE INDEX IF NOT EXISTS idx_examples_created_at ON examples (created_at);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id         TEXT NOT NULL DEFAULT '',
+    aggregate_id     INTEGER NOT NULL,
+    event_type       TEXT NOT NULL,
+    event_version    INTEGER NOT NULL DEFAULT 1,
+    payload          BLOB NOT NULL,
+    occurred_at      DATETIME NOT NULL,
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    last_error       TEXT,
+    next_attempt_at  DATETIME NOT NULL,
+    sent_at          DATETIME,
+    dead_lettered_at DATETIME,
+    created_at       DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate_id ON outbox (aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt_at ON outbox (next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_sent_at ON outbox (sent_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_lettered_at ON outbox (dead_lettered_at);
+
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer     TEXT NOT NULL,
+    event_id     TEXT NOT NULL,
+    event_type   TEXT NOT NULL,
+    processed_at DATETIME,
+    PRIMARY KEY (consumer, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events (processed_at);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                   INTEGER PRIMARY KEY AUTOINCREMENT,
+    url                  TEXT NOT NULL,
+    event_types          TEXT,
+    secret               TEXT NOT NULL,
+    active               BOOLEAN NOT NULL DEFAULT true,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    disabled_at          DATETIME,
+    created_at           DATETIME,
+    updated_at           DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    subscription_id INTEGER NOT NULL,
+    event_id        TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    event_version   INTEGER NOT NULL DEFAULT 1,
+    aggregate_id    INTEGER NOT NULL,
+    payload         BLOB NOT NULL,
+    occurred_at     DATETIME NOT NULL,
+    status          TEXT NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error      TEXT,
+    next_attempt_at DATETIME NOT NULL,
+    delivered_at    DATETIME,
+    created_at      DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
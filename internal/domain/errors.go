@@ -7,5 +7,11 @@ var (
 	ErrExampleNotFound      = errors.New("example not found")
 	ErrExampleAlreadyExists = errors.New("example already exists")
 	ErrInvalidInput         = errors.New("invalid input")
+	ErrVersionConflict      = errors.New("example was modified by another request")
+	ErrEventProcessed       = errors.New("event already processed")
+	ErrWebhookNotFound      = errors.New("webhook subscription not found")
+	ErrChangeFeedStopped    = errors.New("change feed stopped")
+	ErrDeadLetterNotFound   = errors.New("dead-lettered event not found")
+	ErrEventPublishFailed   = errors.New("failed to publish event")
 )
 
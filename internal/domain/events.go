@@ -1,31 +1,86 @@
 package domain
 
-import "time"
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
 
-// Event represents a domain event
+// Event types
+const (
+	EventTypeExampleCreated = "ExampleCreated"
+	EventTypeExampleUpdated = "ExampleUpdated"
+	EventTypeExampleDeleted = "ExampleDeleted"
+)
+
+// ExampleEventVersion is the payload version of the example events this
+// service publishes; version 2 added the example status
+const ExampleEventVersion = 2
+
+// Event represents a domain event. ID is unique per event and stays the same
+// when the event is redelivered, so consumers can deduplicate on it. Version
+// is the schema version of the payload.
 type Event struct {
-	Type      string
-	Payload   interface{}
-	Timestamp time.Time
+	ID          string
+	Type        string
+	Version     int
+	AggregateID int64
+	Payload     interface{}
+	Timestamp   time.Time
 }
 
+// NewEvent creates a version 1 event with a new id
+func NewEvent(eventType string, aggregateID int64, payload interface{}, timestamp time.Time) *Event {
+	return &Event{
+		ID:          NewEventID(),
+		Type:        eventType,
+		Version:     1,
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Timestamp:   timestamp,
+	}
+}
+
+// NewExampleEvent creates an example event at ExampleEventVersion
+func NewExampleEvent(eventType string, exampleID int64, payload interface{}, timestamp time.Time) *Event {
+	event := NewEvent(eventType, exampleID, payload, timestamp)
+	event.Version = ExampleEventVersion
+	return event
+}
+
+// NewEventID returns a random (version 4) UUID
+func NewEventID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Event payloads at ExampleEventVersion. The json names match the protobuf
+// messages in proto/events.proto, which define the published schema.
+
 // ExampleCreatedEvent represents an example creation event
 type ExampleCreatedEvent struct {
-	ExampleID int64
-	Name      string
-	Timestamp time.Time
+	ExampleID int64     `json:"example_id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // ExampleUpdatedEvent represents an example update event
 type ExampleUpdatedEvent struct {
-	ExampleID int64
-	Name      string
-	Timestamp time.Time
+	ExampleID int64     `json:"example_id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // ExampleDeletedEvent represents an example deletion event
 type ExampleDeletedEvent struct {
-	ExampleID int64
-	Timestamp time.Time
+	ExampleID int64     `json:"example_id"`
+	Timestamp time.Time `json:"timestamp"`
+	// Status is the status the example had when it was deleted
+	Status string `json:"status,omitempty"`
 }
 
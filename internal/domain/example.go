@@ -6,8 +6,10 @@ import "time"
 type Example struct {
 	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
-	Status    string    `gorm:"type:varchar(50);default:'active'" json:"status"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Status    string    `gorm:"type:varchar(50);default:'active';index" json:"status"`
+	AccountID string    `gorm:"type:varchar(255);not null;default:'';index" json:"account_id"`
+	Version   int64     `gorm:"not null;default:1" json:"version"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
@@ -19,13 +21,13 @@ func (e *Example) IsActive() bool {
 // Activate marks the example as active
 func (e *Example) Activate() {
 	e.Status = "active"
-	e.UpdatedAt = time.Now()
+	e.UpdatedAt = time.Now().UTC()
 }
 
 // Deactivate marks the example as inactive
 func (e *Example) Deactivate() {
 	e.Status = "inactive"
-	e.UpdatedAt = time.Now()
+	e.UpdatedAt = time.Now().UTC()
 }
 
 // TableName specifies the table name for GORM
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// InboundEvent is an event received from another service
+type InboundEvent struct {
+	ID          string
+	Source      string
+	Type        string
+	Subject     string
+	Version     int
+	Time        time.Time
+	ContentType string
+	Data        []byte
+}
+
+// Inbound event types
+const (
+	EventTypeAccountClosed = "AccountClosed"
+)
+
+// AccountClosedEvent is the payload of an AccountClosed event from the
+// accounts service
+type AccountClosedEvent struct {
+	AccountID string `json:"account_id"`
+}
+
+// ProcessedEvent records that a consumer has handled an inbound event, so
+// redelivered events are processed only once
+type ProcessedEvent struct {
+	Consumer    string    `gorm:"type:varchar(100);primaryKey" json:"consumer"`
+	EventID     string    `gorm:"type:varchar(255);primaryKey" json:"event_id"`
+	EventType   string    `gorm:"type:varchar(100);not null" json:"event_type"`
+	ProcessedAt time.Time `gorm:"autoCreateTime;index" json:"processed_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ProcessedEvent) TableName() string {
+	return "processed_events"
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// OutboxMessage is a domain event stored in the same transaction as the
+// entity change that produced it, waiting to be relayed to the broker
+type OutboxMessage struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID       string     `gorm:"type:varchar(36);not null;default:''" json:"event_id"`
+	AggregateID   int64      `gorm:"not null;index" json:"aggregate_id"`
+	EventType     string     `gorm:"type:varchar(100);not null" json:"event_type"`
+	EventVersion  int        `gorm:"not null;default:1" json:"event_version"`
+	Payload       []byte     `gorm:"not null" json:"payload"`
+	OccurredAt    time.Time  `gorm:"not null" json:"occurred_at"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	NextAttemptAt time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	SentAt        *time.Time `gorm:"index" json:"sent_at"`
+	// DeadLetteredAt is set when the relay gave up on the message; it stays
+	// parked until it is replayed
+	DeadLetteredAt *time.Time `gorm:"index" json:"dead_lettered_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookSubscription is a partner endpoint that receives example events
+type WebhookSubscription struct {
+	ID  int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL string `gorm:"type:text;not null" json:"url"`
+	// EventTypes filters the events delivered; empty means all events
+	EventTypes []string `gorm:"type:text;serializer:json" json:"event_types"`
+	// Secret signs every delivery; it is only shown when the subscription is created
+	Secret string `gorm:"type:varchar(255);not null" json:"-"`
+	Active bool   `gorm:"not null;default:true" json:"active"`
+	// ConsecutiveFailures counts failed attempts since the last success; the
+	// subscription is disabled when it reaches the configured limit
+	ConsecutiveFailures int        `gorm:"not null;default:0" json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at"`
+	CreatedAt           time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Accepts reports whether the subscription wants events of the given type
+func (s *WebhookSubscription) Accepts(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for, or delivered to, a subscription.
+// Deliveries double as the delivery log.
+type WebhookDelivery struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionID int64      `gorm:"not null;index" json:"subscription_id"`
+	EventID        string     `gorm:"type:varchar(255);not null" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(100);not null" json:"event_type"`
+	EventVersion   int        `gorm:"not null;default:1" json:"event_version"`
+	AggregateID    int64      `gorm:"not null" json:"aggregate_id"`
+	Payload        []byte     `gorm:"not null" json:"-"`
+	OccurredAt     time.Time  `gorm:"not null" json:"occurred_at"`
+	Status         string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `gorm:"type:text" json:"last_error"`
+	NextAttemptAt  time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,21 @@
+package external
+
+// SubscriberStats are the delivery counters of an in-process event subscriber
+type SubscriberStats struct {
+	Name      string
+	Mode      string
+	Delivered uint64
+	Failed    uint64
+	Panics    uint64
+	// Dropped counts events an async subscriber missed because its queue
+	// was full
+	Dropped   uint64
+	LastError string
+}
+
+// SubscriberStatsReporter defines the interface for reading the counters of
+// in-process event subscribers
+type SubscriberStatsReporter interface {
+	// Stats returns the counters of every subscriber
+	Stats() []SubscriberStats
+}
@@ -1,10 +1,15 @@
 package external
 
-import "example-service/internal/domain"
+import (
+	"context"
+	"example-service/internal/domain"
+)
 
 // EventPublisher defines the interface for publishing domain events
 type EventPublisher interface {
 	// Publish publishes a domain event
-	Publish(event *domain.Event) error
-}
+	Publish(ctx context.Context, event *domain.Event) error
 
+	// Close flushes any buffered events and releases resources
+	Close() error
+}
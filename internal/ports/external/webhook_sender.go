@@ -0,0 +1,14 @@
+package external
+
+import (
+	"context"
+	"example-service/internal/domain"
+)
+
+// WebhookSender defines the interface for delivering events to webhook endpoints
+type WebhookSender interface {
+	// Send posts a delivery to the subscription's URL, signed with its
+	// secret, and returns the HTTP status received. Non-2xx statuses are
+	// returned as errors.
+	Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error)
+}
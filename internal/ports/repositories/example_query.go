@@ -0,0 +1,43 @@
+package repositories
+
+import "time"
+
+// ExampleSortField is a column examples can be ordered by
+type ExampleSortField string
+
+// Sortable example fields
+const (
+	SortByID        ExampleSortField = "id"
+	SortByName      ExampleSortField = "name"
+	SortByCreatedAt ExampleSortField = "created_at"
+	SortByUpdatedAt ExampleSortField = "updated_at"
+)
+
+// ExampleFilter restricts the examples returned by a listing. Zero values
+// mean no restriction.
+type ExampleFilter struct {
+	Status        string
+	NamePrefix    string
+	AccountID     string
+	CreatedAfter  time.Time // inclusive
+	CreatedBefore time.Time // exclusive
+}
+
+// ExampleCursor holds the sort keys of the last example of the previous page
+type ExampleCursor struct {
+	ID        int64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ExampleQuery describes one page of a keyset-paginated listing. Results are
+// ordered by OrderBy and then by ID, in the same direction, so that the order
+// is total and After can resume exactly where the previous page stopped.
+type ExampleQuery struct {
+	Filter  ExampleFilter
+	OrderBy ExampleSortField
+	Desc    bool
+	After   *ExampleCursor
+	Limit   int
+}
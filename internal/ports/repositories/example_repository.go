@@ -1,25 +1,30 @@
 package repositories
 
-import "example-service/internal/domain"
+import (
+	"context"
+	"example-service/internal/domain"
+)
 
 // ExampleRepository defines the interface for example data operations
 type ExampleRepository interface {
 	// Create creates a new example
-	Create(example *domain.Example) error
+	Create(ctx context.Context, example *domain.Example) error
 
 	// FindByID finds an example by ID
-	FindByID(id int64) (*domain.Example, error)
+	FindByID(ctx context.Context, id int64) (*domain.Example, error)
 
-	// FindAll finds all examples
-	FindAll() ([]*domain.Example, error)
+	// List finds one page of examples matching the query
+	List(ctx context.Context, query ExampleQuery) ([]*domain.Example, error)
+
+	// Count counts the examples matching the filter
+	Count(ctx context.Context, filter ExampleFilter) (int64, error)
 
 	// Update updates an existing example
-	Update(example *domain.Example) error
+	Update(ctx context.Context, example *domain.Example) error
 
 	// Delete deletes an example by ID
-	Delete(id int64) error
+	Delete(ctx context.Context, id int64) error
 
 	// Exists checks if an example exists with the given name
-	Exists(name string) (bool, error)
+	Exists(ctx context.Context, name string) (bool, error)
 }
-
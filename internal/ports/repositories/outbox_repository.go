@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"example-service/internal/domain"
+	"time"
+)
+
+// OutboxRepository defines the interface for transactional outbox storage
+type OutboxRepository interface {
+	// Add stores a message; call it inside the transaction changing the entity
+	Add(ctx context.Context, message *domain.OutboxMessage) error
+
+	// ClaimPending returns up to limit unsent messages that are due, oldest
+	// first, skipping messages queued behind an unsent message for the same
+	// aggregate so that events are relayed in order per aggregate.
+	// Dead-lettered messages are not returned but keep holding back later
+	// messages for their aggregate until they are requeued.
+	// The messages are claimed for lease: relays sharing the outbox do not
+	// get them again until the lease ends, so a message whose relay stopped
+	// before marking it is retried afterwards.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxMessage, error)
+
+	// MarkSent records that a message was published
+	MarkSent(ctx context.Context, id int64) error
+
+	// MarkFailed records a failed publish attempt and when to retry
+	MarkFailed(ctx context.Context, id int64, lastErr string, nextAttemptAt time.Time) error
+
+	// MarkDeadLettered records a final failed publish attempt and parks the
+	// message
+	MarkDeadLettered(ctx context.Context, id int64, lastErr string) error
+
+	// ListDeadLettered returns up to limit parked messages, most recently
+	// parked first
+	ListDeadLettered(ctx context.Context, limit int) ([]*domain.OutboxMessage, error)
+
+	// CountDeadLettered returns the number of parked messages
+	CountDeadLettered(ctx context.Context) (int64, error)
+
+	// Requeue makes a parked message due again with a fresh attempt count.
+	// It reports false if no parked message has the id.
+	Requeue(ctx context.Context, id int64) (bool, error)
+
+	// ListAfter returns up to limit messages with an id greater than afterID,
+	// sent or not, in id order
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]*domain.OutboxMessage, error)
+
+	// ListByIDs returns the messages with the given ids that exist, in id
+	// order
+	ListByIDs(ctx context.Context, ids []int64) ([]*domain.OutboxMessage, error)
+
+	// LastID returns the id of the newest message, or 0 when there is none
+	LastID(ctx context.Context) (int64, error)
+}
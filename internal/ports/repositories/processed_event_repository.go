@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"example-service/internal/domain"
+)
+
+// ProcessedEventRepository defines the interface for the idempotency table of
+// inbound events
+type ProcessedEventRepository interface {
+	// IsProcessed reports whether consumer has already handled the event
+	IsProcessed(ctx context.Context, consumer, eventID string) (bool, error)
+
+	// MarkProcessed records a handled event; call it in the transaction of
+	// the handler's changes. Returns domain.ErrEventProcessed if the event
+	// was recorded concurrently.
+	MarkProcessed(ctx context.Context, event *domain.ProcessedEvent) error
+}
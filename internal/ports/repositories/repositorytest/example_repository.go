@@ -0,0 +1,468 @@
+// Package repositorytest provides conformance tests for implementations of
+// the repository ports.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ExampleRepositoryFactory returns an empty example repository with case
+// sensitive names. It is called once per subtest.
+type ExampleRepositoryFactory func(t *testing.T) repositories.ExampleRepository
+
+// timestampTolerance allows for databases storing timestamps at microsecond
+// precision
+const timestampTolerance = time.Millisecond
+
+// TestExampleRepository checks that an ExampleRepository behaves like every
+// other implementation. Names used by the suite are lowercase ASCII, so
+// that their order does not depend on the database collation.
+func TestExampleRepository(t *testing.T, newRepo ExampleRepositoryFactory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repositories.ExampleRepository)
+	}{
+		{"Create", testCreate},
+		{"FindByID", testFindByID},
+		{"UniqueNames", testUniqueNames},
+		{"Update", testUpdate},
+		{"Delete", testDelete},
+		{"Exists", testExists},
+		{"ListOrder", testListOrder},
+		{"ListPages", testListPages},
+		{"ListFilters", testListFilters},
+		{"ConcurrentCreate", testConcurrentCreate},
+		{"ConcurrentUpdate", testConcurrentUpdate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+// create stores an example with the given name, failing the test on error
+func create(t *testing.T, repo repositories.ExampleRepository, example *domain.Example) *domain.Example {
+	t.Helper()
+	if err := repo.Create(context.Background(), example); err != nil {
+		t.Fatalf("Create(%q) error = %v", example.Name, err)
+	}
+	return example
+}
+
+// find loads an example, failing the test if it is missing
+func find(t *testing.T, repo repositories.ExampleRepository, id int64) *domain.Example {
+	t.Helper()
+	found, err := repo.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindByID(%d) error = %v", id, err)
+	}
+	if found == nil {
+		t.Fatalf("FindByID(%d) = nil, want example", id)
+	}
+	return found
+}
+
+// sameTime reports whether two timestamps are equal within the tolerance
+func sameTime(a, b time.Time) bool {
+	d := a.Sub(b)
+	return d > -timestampTolerance && d < timestampTolerance
+}
+
+// names joins the names of examples
+func names(examples []*domain.Example) string {
+	parts := make([]string, len(examples))
+	for i, e := range examples {
+		parts[i] = e.Name
+	}
+	return strings.Join(parts, ",")
+}
+
+func testCreate(t *testing.T, repo repositories.ExampleRepository) {
+	before := time.Now()
+	first := create(t, repo, &domain.Example{Name: "first"})
+	second := create(t, repo, &domain.Example{Name: "second", Status: "inactive"})
+
+	if first.ID <= 0 || second.ID <= first.ID {
+		t.Errorf("ids = %d, %d, want increasing positive ids", first.ID, second.ID)
+	}
+	if first.Status != "active" || second.Status != "inactive" {
+		t.Errorf("statuses = %q, %q, want the default active and the given inactive", first.Status, second.Status)
+	}
+	if first.Version != 1 {
+		t.Errorf("version = %d, want 1", first.Version)
+	}
+	if first.CreatedAt.Before(before.Add(-time.Second)) || first.UpdatedAt.IsZero() {
+		t.Errorf("timestamps = %v, %v, want the creation time", first.CreatedAt, first.UpdatedAt)
+	}
+
+	created := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	given := create(t, repo, &domain.Example{Name: "third", CreatedAt: created, UpdatedAt: created})
+	if found := find(t, repo, given.ID); !sameTime(found.CreatedAt, created) || !sameTime(found.UpdatedAt, created) {
+		t.Errorf("stored timestamps = %v, %v, want the given %v", found.CreatedAt, found.UpdatedAt, created)
+	}
+}
+
+func testFindByID(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	example := create(t, repo, &domain.Example{Name: "found"})
+
+	found := find(t, repo, example.ID)
+	if found.ID != example.ID || found.Name != "found" || found.Status != "active" || found.Version != 1 {
+		t.Errorf("FindByID() = %+v, want %+v", found, example)
+	}
+	if !sameTime(found.CreatedAt, example.CreatedAt) || !sameTime(found.UpdatedAt, example.UpdatedAt) {
+		t.Errorf("stored timestamps = %v, %v, want %v, %v", found.CreatedAt, found.UpdatedAt, example.CreatedAt, example.UpdatedAt)
+	}
+
+	// The result is not shared with the repository
+	found.Name = "changed"
+	if again := find(t, repo, example.ID); again.Name != "found" {
+		t.Errorf("name after modifying a result = %q, want found", again.Name)
+	}
+
+	for _, id := range []int64{example.ID + 1000, 0, -1} {
+		missing, err := repo.FindByID(ctx, id)
+		if missing != nil || err != nil {
+			t.Errorf("FindByID(%d) = %+v, %v, want nil, nil", id, missing, err)
+		}
+	}
+}
+
+func testUniqueNames(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	create(t, repo, &domain.Example{Name: "taken"})
+	other := create(t, repo, &domain.Example{Name: "other"})
+
+	if err := repo.Create(ctx, &domain.Example{Name: "taken"}); !errors.Is(err, domain.ErrExampleAlreadyExists) {
+		t.Errorf("Create() with a taken name error = %v, want %v", err, domain.ErrExampleAlreadyExists)
+	}
+	rename := *other
+	rename.Name = "taken"
+	if err := repo.Update(ctx, &rename); !errors.Is(err, domain.ErrExampleAlreadyExists) {
+		t.Errorf("Update() to a taken name error = %v, want %v", err, domain.ErrExampleAlreadyExists)
+	}
+	if found := find(t, repo, other.ID); found.Name != "other" || found.Version != 1 {
+		t.Errorf("example after a failed rename = %+v, want it unchanged", found)
+	}
+	if err := repo.Create(ctx, &domain.Example{Name: "Taken"}); err != nil {
+		t.Errorf("Create() with a name differing in case error = %v, want nil", err)
+	}
+
+	// Keeping its own name is not a conflict
+	same := *other
+	same.Status = "inactive"
+	if err := repo.Update(ctx, &same); err != nil {
+		t.Errorf("Update() keeping the name error = %v", err)
+	}
+}
+
+func testUpdate(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	example := create(t, repo, &domain.Example{Name: "before"})
+
+	updatedAt := example.UpdatedAt.Add(time.Hour)
+	update := &domain.Example{ID: example.ID, Name: "after", Status: "inactive", Version: 1, CreatedAt: example.CreatedAt, UpdatedAt: updatedAt}
+	if err := repo.Update(ctx, update); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if update.Version != 2 {
+		t.Errorf("version after Update() = %d, want 2", update.Version)
+	}
+	found := find(t, repo, example.ID)
+	if found.Name != "after" || found.Status != "inactive" || found.Version != 2 {
+		t.Errorf("stored example = %+v, want the update at version 2", found)
+	}
+	if !sameTime(found.UpdatedAt, updatedAt) || !sameTime(found.CreatedAt, example.CreatedAt) {
+		t.Errorf("stored timestamps = %v, %v, want %v, %v", found.CreatedAt, found.UpdatedAt, example.CreatedAt, updatedAt)
+	}
+
+	stale := &domain.Example{ID: example.ID, Name: "stale", Status: "active", Version: 1}
+	if err := repo.Update(ctx, stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("Update() at a stale version error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+	if stale.Version != 1 {
+		t.Errorf("version after a failed Update() = %d, want 1", stale.Version)
+	}
+	missing := &domain.Example{ID: example.ID + 1000, Name: "missing", Status: "active", Version: 1}
+	if err := repo.Update(ctx, missing); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("Update() of a missing example error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+	if found := find(t, repo, example.ID); found.Name != "after" || found.Version != 2 {
+		t.Errorf("example after failed updates = %+v, want it unchanged", found)
+	}
+}
+
+func testDelete(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	deleted := create(t, repo, &domain.Example{Name: "deleted"})
+	kept := create(t, repo, &domain.Example{Name: "kept"})
+
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if found, err := repo.FindByID(ctx, deleted.ID); found != nil || err != nil {
+		t.Errorf("FindByID() after Delete() = %+v, %v, want nil, nil", found, err)
+	}
+	find(t, repo, kept.ID)
+
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Errorf("Delete() of a deleted example error = %v, want nil", err)
+	}
+	if err := repo.Delete(ctx, kept.ID+1000); err != nil {
+		t.Errorf("Delete() of a missing example error = %v, want nil", err)
+	}
+
+	// The name is free again, but the id is not reused
+	again := create(t, repo, &domain.Example{Name: "deleted"})
+	if again.ID <= kept.ID {
+		t.Errorf("id after Delete() = %d, want more than %d", again.ID, kept.ID)
+	}
+	if err := repo.Update(ctx, &domain.Example{ID: deleted.ID, Name: "deleted", Status: "active", Version: 1}); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("Update() of a deleted example error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+}
+
+func testExists(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	example := create(t, repo, &domain.Example{Name: "present"})
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"present", true},
+		{"absent", false},
+		{"Present", false},
+		{"pres", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		got, err := repo.Exists(ctx, tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("Exists(%q) = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	if err := repo.Delete(ctx, example.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got, err := repo.Exists(ctx, "present"); err != nil || got {
+		t.Errorf("Exists() after Delete() = %v, %v, want false", got, err)
+	}
+}
+
+// seedList stores examples for the listing tests: names in a different order
+// than ids, one creation time shared by two examples and one inactive example
+func seedList(t *testing.T, repo repositories.ExampleRepository) time.Time {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	seeds := []struct {
+		name    string
+		status  string
+		created time.Duration
+	}{
+		{"cherry", "active", 2 * time.Hour},
+		{"apple", "active", 0},
+		{"banana", "inactive", time.Hour},
+		{"date", "active", 2 * time.Hour},
+		{"apricot", "active", 3 * time.Hour},
+	}
+	for _, s := range seeds {
+		at := base.Add(s.created)
+		create(t, repo, &domain.Example{Name: s.name, Status: s.status, CreatedAt: at, UpdatedAt: at.Add(time.Minute)})
+	}
+	return base
+}
+
+func testListOrder(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	seedList(t, repo)
+
+	tests := []struct {
+		orderBy repositories.ExampleSortField
+		desc    bool
+		want    string
+	}{
+		{"", false, "cherry,apple,banana,date,apricot"},
+		{repositories.SortByID, true, "apricot,date,banana,apple,cherry"},
+		{repositories.SortByName, false, "apple,apricot,banana,cherry,date"},
+		{repositories.SortByName, true, "date,cherry,banana,apricot,apple"},
+		{repositories.SortByCreatedAt, false, "apple,banana,cherry,date,apricot"},
+		{repositories.SortByCreatedAt, true, "apricot,date,cherry,banana,apple"},
+		{repositories.SortByUpdatedAt, false, "apple,banana,cherry,date,apricot"},
+	}
+	for _, tt := range tests {
+		examples, err := repo.List(ctx, repositories.ExampleQuery{OrderBy: tt.orderBy, Desc: tt.desc})
+		if err != nil {
+			t.Fatalf("List(%s, desc=%v) error = %v", tt.orderBy, tt.desc, err)
+		}
+		if got := names(examples); got != tt.want {
+			t.Errorf("List(%s, desc=%v) = %s, want %s", tt.orderBy, tt.desc, got, tt.want)
+		}
+	}
+
+	if _, err := repo.List(ctx, repositories.ExampleQuery{OrderBy: "secret"}); err == nil {
+		t.Error("List() with an unknown sort field error = nil, want error")
+	}
+	if examples, err := repo.List(ctx, repositories.ExampleQuery{Limit: 2}); err != nil || len(examples) != 2 {
+		t.Errorf("List(limit 2) = %d examples, %v, want 2", len(examples), err)
+	}
+}
+
+func testListPages(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	seedList(t, repo)
+
+	fields := []repositories.ExampleSortField{repositories.SortByID, repositories.SortByName, repositories.SortByCreatedAt, repositories.SortByUpdatedAt}
+	for _, field := range fields {
+		for _, desc := range []bool{false, true} {
+			all, err := repo.List(ctx, repositories.ExampleQuery{OrderBy: field, Desc: desc})
+			if err != nil {
+				t.Fatalf("List(%s, desc=%v) error = %v", field, desc, err)
+			}
+
+			// Walking two examples at a time returns the same sequence
+			var paged []*domain.Example
+			query := repositories.ExampleQuery{OrderBy: field, Desc: desc, Limit: 2}
+			for pages := 0; ; pages++ {
+				if pages > len(all) {
+					t.Fatalf("List(%s, desc=%v) pagination did not terminate", field, desc)
+				}
+				page, err := repo.List(ctx, query)
+				if err != nil {
+					t.Fatalf("List(%s, desc=%v) error = %v", field, desc, err)
+				}
+				paged = append(paged, page...)
+				if len(page) < query.Limit {
+					break
+				}
+				last := page[len(page)-1]
+				query.After = &repositories.ExampleCursor{ID: last.ID, Name: last.Name, CreatedAt: last.CreatedAt, UpdatedAt: last.UpdatedAt}
+			}
+			if names(paged) != names(all) {
+				t.Errorf("pages of List(%s, desc=%v) = %s, want %s", field, desc, names(paged), names(all))
+			}
+		}
+	}
+}
+
+func testListFilters(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	base := seedList(t, repo)
+	create(t, repo, &domain.Example{Name: "a%_b", CreatedAt: base.Add(10 * time.Hour), UpdatedAt: base.Add(10 * time.Hour)})
+	create(t, repo, &domain.Example{Name: "axxb", CreatedAt: base.Add(10 * time.Hour), UpdatedAt: base.Add(10 * time.Hour)})
+	create(t, repo, &domain.Example{Name: "Apple", CreatedAt: base.Add(10 * time.Hour), UpdatedAt: base.Add(10 * time.Hour)})
+	create(t, repo, &domain.Example{Name: "owned", AccountID: "acc-1", CreatedAt: base.Add(10 * time.Hour), UpdatedAt: base.Add(10 * time.Hour)})
+
+	tests := []struct {
+		name   string
+		filter repositories.ExampleFilter
+		want   string
+	}{
+		{"status", repositories.ExampleFilter{Status: "inactive"}, "banana"},
+		{"name prefix", repositories.ExampleFilter{NamePrefix: "ap"}, "apple,apricot"},
+		{"name prefix is case sensitive", repositories.ExampleFilter{NamePrefix: "A"}, "Apple"},
+		{"name prefix wildcards are literal", repositories.ExampleFilter{NamePrefix: "a%_"}, "a%_b"},
+		{"account", repositories.ExampleFilter{AccountID: "acc-1"}, "owned"},
+		{"created after is inclusive", repositories.ExampleFilter{CreatedAfter: base.Add(2 * time.Hour), CreatedBefore: base.Add(10 * time.Hour)}, "cherry,date,apricot"},
+		{"created before is exclusive", repositories.ExampleFilter{CreatedBefore: base.Add(2 * time.Hour)}, "apple,banana"},
+		{"combined", repositories.ExampleFilter{Status: "active", NamePrefix: "a", CreatedBefore: base.Add(3 * time.Hour)}, "apple"},
+		{"no match", repositories.ExampleFilter{NamePrefix: "zzz"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			examples, err := repo.List(ctx, repositories.ExampleQuery{Filter: tt.filter, OrderBy: repositories.SortByCreatedAt})
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if got := names(examples); got != tt.want {
+				t.Errorf("List() = %s, want %s", got, tt.want)
+			}
+			count, err := repo.Count(ctx, tt.filter)
+			if err != nil || count != int64(len(examples)) {
+				t.Errorf("Count() = %d, %v, want %d", count, err, len(examples))
+			}
+		})
+	}
+}
+
+func testConcurrentCreate(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	const workers = 20
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Every name is created twice
+			errs[i] = repo.Create(ctx, &domain.Example{Name: fmt.Sprintf("example-%02d", i/2)})
+		}(i)
+	}
+	wg.Wait()
+
+	conflicts := 0
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, domain.ErrExampleAlreadyExists):
+			conflicts++
+		case err != nil:
+			t.Errorf("Create() error = %v", err)
+		}
+	}
+	examples, err := repo.List(ctx, repositories.ExampleQuery{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	ids := make(map[int64]bool)
+	for _, e := range examples {
+		ids[e.ID] = true
+	}
+	if conflicts != workers/2 || len(examples) != workers/2 || len(ids) != workers/2 {
+		t.Errorf("got %d conflicts and %d examples with %d ids, want %d each", conflicts, len(examples), len(ids), workers/2)
+	}
+}
+
+func testConcurrentUpdate(t *testing.T, repo repositories.ExampleRepository) {
+	ctx := context.Background()
+	example := create(t, repo, &domain.Example{Name: "contended"})
+	const workers = 10
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			update := *example
+			update.Status = "inactive"
+			errs[i] = repo.Update(ctx, &update)
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, domain.ErrVersionConflict):
+			t.Errorf("Update() error = %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d concurrent updates at the same version succeeded, want 1", succeeded)
+	}
+	if found := find(t, repo, example.ID); found.Version != 2 {
+		t.Errorf("version = %d, want 2", found.Version)
+	}
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "context"
+
+// Transactor runs a function inside a database transaction, the unit of
+// work of a use case. Repositories called with the context passed to fn take
+// part in the transaction, which is committed when fn returns nil and rolled
+// back when it returns an error or panics. Nested calls run inside a
+// savepoint: a failing inner call only undoes its own writes, and the outer
+// call decides whether to carry on.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"example-service/internal/domain"
+	"time"
+)
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription storage
+type WebhookSubscriptionRepository interface {
+	// Create stores a new subscription
+	Create(ctx context.Context, subscription *domain.WebhookSubscription) error
+
+	// FindByID finds a subscription by ID, returning nil if there is none
+	FindByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error)
+
+	// List returns every subscription, oldest first
+	List(ctx context.Context) ([]*domain.WebhookSubscription, error)
+
+	// ListActive returns the subscriptions that receive deliveries
+	ListActive(ctx context.Context) ([]*domain.WebhookSubscription, error)
+
+	// RecordSuccess resets the consecutive failures of a subscription
+	RecordSuccess(ctx context.Context, id int64) error
+
+	// RecordFailure counts a failed attempt against a subscription and
+	// disables it when its consecutive failures reach disableAfter. It
+	// reports whether this failure disabled the subscription.
+	RecordFailure(ctx context.Context, id int64, disableAfter int) (bool, error)
+
+	// Delete removes a subscription and its deliveries
+	Delete(ctx context.Context, id int64) error
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery
+// queues and log
+type WebhookDeliveryRepository interface {
+	// Enqueue stores a pending delivery; call it inside the transaction
+	// changing the entity
+	Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ClaimDue returns up to limit due deliveries, at most one per active
+	// subscription: the oldest pending delivery of its queue. The
+	// deliveries are claimed for lease: dispatchers sharing the queues do not
+	// get them again until the lease ends, so a delivery whose dispatcher
+	// stopped before recording the attempt is retried afterwards.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+
+	// MarkSucceeded records a successful attempt
+	MarkSucceeded(ctx context.Context, id int64, responseStatus int) error
+
+	// MarkRetrying records a failed attempt and when to retry
+	MarkRetrying(ctx context.Context, id int64, responseStatus int, lastErr string, nextAttemptAt time.Time) error
+
+	// MarkFailed records a final failed attempt
+	MarkFailed(ctx context.Context, id int64, responseStatus int, lastErr string) error
+
+	// ListBySubscription returns the most recent deliveries of a subscription, newest first
+	ListBySubscription(ctx context.Context, subscriptionID int64, limit int) ([]*domain.WebhookDelivery, error)
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"example-service/internal/application/dto"
+)
+
+// EventAdminService defines the interface for inspecting event publishing
+// and replaying dead-lettered events
+type EventAdminService interface {
+	// ListDeadLetters retrieves the most recently parked events
+	ListDeadLetters(ctx context.Context, limit int) (*dto.ListDeadLettersResponse, error)
+
+	// ReplayDeadLetter queues a parked event for publishing again
+	ReplayDeadLetter(ctx context.Context, id int64) error
+
+	// GetPublishStats retrieves the event publishing counters
+	GetPublishStats(ctx context.Context) (*dto.PublishStatsResponse, error)
+}
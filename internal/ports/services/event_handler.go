@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+	"example-service/internal/domain"
+)
+
+// EventHandler is the inbound port for events received from other services
+type EventHandler interface {
+	// HandleEvent processes an event at most once per event id. Errors
+	// wrapping domain.ErrInvalidInput are permanent and must not be retried.
+	HandleEvent(ctx context.Context, event *domain.InboundEvent) error
+}
@@ -1,22 +1,28 @@
 package services
 
-import "example-service/internal/application/dto"
+import (
+	"context"
+	"example-service/internal/application/dto"
+)
 
 // ExampleService defines the interface for example business operations
 type ExampleService interface {
 	// CreateExample creates a new example
-	CreateExample(req *dto.CreateExampleRequest) (*dto.ExampleResponse, error)
+	CreateExample(ctx context.Context, req *dto.CreateExampleRequest) (*dto.ExampleResponse, error)
 
 	// GetExample retrieves an example by ID
-	GetExample(id int64) (*dto.ExampleResponse, error)
+	GetExample(ctx context.Context, id int64) (*dto.ExampleResponse, error)
 
-	// ListExamples retrieves all examples
-	ListExamples() ([]*dto.ExampleResponse, error)
+	// ListExamples retrieves one page of examples
+	ListExamples(ctx context.Context, req *dto.ListExamplesRequest) (*dto.ListExamplesResponse, error)
 
 	// UpdateExample updates an existing example
-	UpdateExample(id int64, req *dto.UpdateExampleRequest) (*dto.ExampleResponse, error)
+	UpdateExample(ctx context.Context, id int64, req *dto.UpdateExampleRequest) (*dto.ExampleResponse, error)
 
 	// DeleteExample deletes an example by ID
-	DeleteExample(id int64) error
-}
+	DeleteExample(ctx context.Context, id int64) error
 
+	// DeactivateAccountExamples deactivates the active examples owned by an
+	// account and returns how many were deactivated
+	DeactivateAccountExamples(ctx context.Context, accountID string) (int, error)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"example-service/internal/application/dto"
+)
+
+// ExampleWatcher defines the interface for following example changes
+type ExampleWatcher interface {
+	// WatchExamples calls fn with each change matching the request, in
+	// order, until ctx is cancelled or fn returns an error
+	WatchExamples(ctx context.Context, req *dto.WatchExamplesRequest, fn func(*dto.ExampleChange) error) error
+
+	// ValidateWatch returns the error WatchExamples would return for an
+	// invalid request, without starting a watch
+	ValidateWatch(req *dto.WatchExamplesRequest) error
+}
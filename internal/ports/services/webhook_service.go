@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"example-service/internal/application/dto"
+)
+
+// WebhookService defines the interface for managing webhook subscriptions
+type WebhookService interface {
+	// CreateWebhook subscribes an endpoint to example events
+	CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+
+	// GetWebhook retrieves a subscription by ID
+	GetWebhook(ctx context.Context, id int64) (*dto.WebhookResponse, error)
+
+	// ListWebhooks retrieves all subscriptions
+	ListWebhooks(ctx context.Context) (*dto.ListWebhooksResponse, error)
+
+	// DeleteWebhook removes a subscription and its delivery log
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	// ListDeliveries retrieves the delivery log of a subscription
+	ListDeliveries(ctx context.Context, id int64, limit int) (*dto.ListWebhookDeliveriesResponse, error)
+}
@@ -0,0 +1,216 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SpecVersion is the CloudEvents specification version emitted
+const SpecVersion = "1.0"
+
+// Content types for the structured and binary content modes
+const (
+	StructuredContentType = "application/cloudevents+json"
+	JSONContentType       = "application/json"
+	ProtobufContentType   = "application/protobuf"
+)
+
+// Content modes
+const (
+	// ModeBinary carries attributes in transport headers and data as the body
+	ModeBinary = "binary"
+	// ModeStructured carries the whole event as a JSON document
+	ModeStructured = "structured"
+)
+
+// ErrInvalid is returned for events missing required attributes
+var ErrInvalid = errors.New("invalid cloudevent")
+
+// Event is a CloudEvents 1.0 event. DataVersion is the dataversion
+// extension, the version of the data schema. In the structured mode, JSON
+// data is embedded as is and any other data is base64 encoded in data_base64.
+type Event struct {
+	SpecVersion     string
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	DataVersion     int
+	Data            []byte
+}
+
+// structuredEvent is the JSON form of an Event
+type structuredEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	DataVersion     int             `json:"dataversion,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      []byte          `json:"data_base64,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (e Event) MarshalJSON() ([]byte, error) {
+	se := structuredEvent{
+		SpecVersion:     e.SpecVersion,
+		ID:              e.ID,
+		Source:          e.Source,
+		Type:            e.Type,
+		Subject:         e.Subject,
+		Time:            e.Time,
+		DataContentType: e.DataContentType,
+		DataSchema:      e.DataSchema,
+		DataVersion:     e.DataVersion,
+	}
+	if IsJSON(e.DataContentType) {
+		se.Data = e.Data
+	} else {
+		se.DataBase64 = e.Data
+	}
+	return json.Marshal(se)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var se structuredEvent
+	if err := json.Unmarshal(b, &se); err != nil {
+		return err
+	}
+	*e = Event{
+		SpecVersion:     se.SpecVersion,
+		ID:              se.ID,
+		Source:          se.Source,
+		Type:            se.Type,
+		Subject:         se.Subject,
+		Time:            se.Time,
+		DataContentType: se.DataContentType,
+		DataSchema:      se.DataSchema,
+		DataVersion:     se.DataVersion,
+		Data:            se.Data,
+	}
+	if se.DataBase64 != nil {
+		e.Data = se.DataBase64
+	}
+	return nil
+}
+
+// Validate checks that the required attributes are present
+func (e *Event) Validate() error {
+	switch {
+	case e.SpecVersion != SpecVersion:
+		return fmt.Errorf("%w: unsupported specversion %q", ErrInvalid, e.SpecVersion)
+	case e.ID == "":
+		return fmt.Errorf("%w: id is required", ErrInvalid)
+	case e.Source == "":
+		return fmt.Errorf("%w: source is required", ErrInvalid)
+	case e.Type == "":
+		return fmt.Errorf("%w: type is required", ErrInvalid)
+	}
+	return nil
+}
+
+// MarshalStructured encodes the event in the structured JSON content mode
+func MarshalStructured(e *Event) ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(e)
+}
+
+// UnmarshalStructured decodes an event in the structured JSON content mode
+func UnmarshalStructured(b []byte) (*Event, error) {
+	var e Event
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// BinaryAttributes returns the attributes for the binary content mode, keyed
+// by attribute name. Transports add their own prefix (ce_ for Kafka, ce- for
+// HTTP) and carry datacontenttype as their content type header.
+func BinaryAttributes(e *Event) (map[string]string, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	attrs := map[string]string{
+		"specversion": e.SpecVersion,
+		"id":          e.ID,
+		"source":      e.Source,
+		"type":        e.Type,
+	}
+	if e.Subject != "" {
+		attrs["subject"] = e.Subject
+	}
+	if !e.Time.IsZero() {
+		attrs["time"] = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if e.DataSchema != "" {
+		attrs["dataschema"] = e.DataSchema
+	}
+	if e.DataVersion != 0 {
+		attrs["dataversion"] = strconv.Itoa(e.DataVersion)
+	}
+	return attrs, nil
+}
+
+// FromBinary rebuilds an event from binary mode attributes and data
+func FromBinary(attrs map[string]string, contentType string, data []byte) (*Event, error) {
+	e := &Event{
+		SpecVersion:     attrs["specversion"],
+		ID:              attrs["id"],
+		Source:          attrs["source"],
+		Type:            attrs["type"],
+		Subject:         attrs["subject"],
+		DataContentType: contentType,
+		DataSchema:      attrs["dataschema"],
+		Data:            data,
+	}
+	if t := attrs["time"]; t != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return nil, fmt.Errorf("%w: time: %v", ErrInvalid, err)
+		}
+		e.Time = parsed
+	}
+	if v := attrs["dataversion"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: dataversion: %v", ErrInvalid, err)
+		}
+		e.DataVersion = n
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// IsJSON reports whether a data content type is JSON. A missing content
+// type means JSON, as in the structured mode.
+func IsJSON(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "" || mediaType == JSONContentType || strings.HasSuffix(mediaType, "+json")
+}
+
+// IsStructured reports whether a content type denotes the structured mode
+func IsStructured(contentType string) bool {
+	return strings.HasPrefix(contentType, StructuredContentType)
+}
@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -9,19 +11,35 @@ import (
 type ErrorCode string
 
 const (
-	CodeInternal     ErrorCode = "INTERNAL_ERROR"
-	CodeNotFound     ErrorCode = "NOT_FOUND"
-	CodeInvalidInput ErrorCode = "INVALID_INPUT"
-	CodeUnauthorized ErrorCode = "UNAUTHORIZED"
-	CodeForbidden    ErrorCode = "FORBIDDEN"
+	CodeInternal           ErrorCode = "INTERNAL_ERROR"
+	CodeNotFound           ErrorCode = "NOT_FOUND"
+	CodeInvalidInput       ErrorCode = "INVALID_INPUT"
+	CodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	CodeForbidden          ErrorCode = "FORBIDDEN"
+	CodeConflict           ErrorCode = "ALREADY_EXISTS"
+	CodeTimeout            ErrorCode = "TIMEOUT"
+	CodeCanceled           ErrorCode = "CANCELED"
+	CodeUnavailable        ErrorCode = "UNAVAILABLE"
+	CodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
 )
 
+// StatusClientClosedRequest is the non-standard status used when the client
+// goes away before the request completes
+const StatusClientClosedRequest = 499
+
+// FieldViolation describes why a single request field is invalid
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
 // AppError represents an application error
 type AppError struct {
 	Code    ErrorCode
 	Message string
 	Status  int
 	Err     error
+	Fields  []FieldViolation
 }
 
 // Error implements the error interface
@@ -32,6 +50,23 @@ func (e *AppError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the wrapped error
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary reports whether the failure is transient and the request may be retried
+func (e *AppError) Temporary() bool {
+	return e.Code == CodeTimeout || e.Code == CodeUnavailable
+}
+
+// WithFields returns a copy of the error carrying the given field violations
+func (e *AppError) WithFields(fields ...FieldViolation) *AppError {
+	cp := *e
+	cp.Fields = append(append([]FieldViolation(nil), e.Fields...), fields...)
+	return &cp
+}
+
 // New creates a new application error
 func New(code ErrorCode, message string, status int) *AppError {
 	return &AppError{
@@ -60,3 +95,31 @@ var (
 	ErrForbidden    = New(CodeForbidden, "Forbidden", http.StatusForbidden)
 )
 
+// ErrorResponse is the JSON body returned for failed HTTP requests
+type ErrorResponse struct {
+	Code      ErrorCode        `json:"code"`
+	Message   string           `json:"message"`
+	RequestID string           `json:"request_id,omitempty"`
+	Fields    []FieldViolation `json:"fields,omitempty"`
+}
+
+// As returns the AppError in err's chain, if any
+func As(err error) (*AppError, bool) {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// WriteJSON writes the application error as a JSON error response
+func WriteJSON(w http.ResponseWriter, err *AppError, requestID string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.Status)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Code:      err.Code,
+		Message:   err.Message,
+		RequestID: requestID,
+		Fields:    err.Fields,
+	})
+}
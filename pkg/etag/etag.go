@@ -0,0 +1,34 @@
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is returned for If-Match values that are not a single version tag
+var ErrInvalid = errors.New("invalid entity tag")
+
+// Format formats a resource version as a strong entity tag
+func Format(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch parses an If-Match header produced from Format. It reports
+// ok=false when the header is empty or "*", meaning any version matches.
+func ParseIfMatch(header string) (version int64, ok bool, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, false, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false, ErrInvalid
+	}
+	version, err = strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, false, ErrInvalid
+	}
+	return version, true, nil
+}
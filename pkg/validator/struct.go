@@ -0,0 +1,157 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError describes a single field that failed validation
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// Error implements the error interface
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors is the list of every field that failed validation
+type ValidationErrors []FieldError
+
+// Error implements the error interface
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateStruct evaluates the `validate` struct tags of s, which must be a
+// struct or a pointer to one, and returns every failing field. Fields are
+// reported by their json name. Supported rules are required, omitempty,
+// min=N, max=N (string length or numeric value) and oneof=a b c.
+func ValidateStruct(s interface{}) error {
+	v := reflect.ValueOf(s)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ValidationErrors{{Rule: "required", Message: "request is required"}}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: ValidateStruct called with %s", v.Kind()))
+	}
+
+	var errs ValidationErrors
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("validate")
+		if tag == "" || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		if fe, ok := validateField(fieldName(sf), v.Field(i), tag); !ok {
+			errs = append(errs, fe)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateField applies the rules in tag to a single field, stopping at the
+// first rule the field breaks
+func validateField(name string, fv reflect.Value, tag string) (FieldError, bool) {
+	for _, rule := range strings.Split(tag, ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		switch key {
+		case "omitempty":
+			if fv.IsZero() {
+				return FieldError{}, true
+			}
+		case "required":
+			if fv.IsZero() || (fv.Kind() == reflect.String && !ValidateNotEmpty(fv.String())) {
+				return fieldError(name, key, "%s is required", name), false
+			}
+		case "min":
+			n := mustInt(param)
+			if size(fv) < n {
+				return fieldError(name, key, "%s must be at least %s", name, bound(fv, param)), false
+			}
+		case "max":
+			n := mustInt(param)
+			if size(fv) > n {
+				return fieldError(name, key, "%s must be at most %s", name, bound(fv, param)), false
+			}
+		case "oneof":
+			options := strings.Fields(param)
+			if !contains(options, fmt.Sprint(fv.Interface())) {
+				return fieldError(name, key, "%s must be one of: %s", name, strings.Join(options, ", ")), false
+			}
+		default:
+			panic(fmt.Sprintf("validator: unknown rule %q on field %s", key, name))
+		}
+	}
+	return FieldError{}, true
+}
+
+// fieldName returns the json name of a struct field, falling back to its Go name
+func fieldName(sf reflect.StructField) string {
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return sf.Name
+}
+
+// size returns the length of strings and the value of integers
+func size(fv reflect.Value) int {
+	switch fv.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(strings.TrimSpace(fv.String()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(fv.Uint())
+	case reflect.Slice, reflect.Map:
+		return fv.Len()
+	default:
+		panic(fmt.Sprintf("validator: min/max not supported on %s", fv.Kind()))
+	}
+}
+
+// bound describes a min/max parameter for error messages
+func bound(fv reflect.Value, param string) string {
+	if fv.Kind() == reflect.String {
+		return param + " characters"
+	}
+	return param
+}
+
+func fieldError(name, rule, format string, args ...interface{}) FieldError {
+	return FieldError{Field: name, Rule: rule, Message: fmt.Sprintf(format, args...)}
+}
+
+func mustInt(param string) int {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		panic(fmt.Sprintf("validator: invalid rule parameter %q", param))
+	}
+	return n
+}
+
+func contains(options []string, s string) bool {
+	for _, o := range options {
+		if o == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Delivery headers
+const (
+	// SignatureHeader carries the timestamp and signature of a delivery,
+	// e.g. "t=1700000000,v1=5257a869..."
+	SignatureHeader = "X-Webhook-Signature"
+	// IDHeader carries the event id, the same for every attempt
+	IDHeader = "X-Webhook-Id"
+	// EventHeader carries the event type
+	EventHeader = "X-Webhook-Event"
+)
+
+// DefaultTolerance is the maximum age of a signature accepted by Verify
+const DefaultTolerance = 5 * time.Minute
+
+// Verification errors
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrExpiredSignature = errors.New("webhook signature timestamp outside tolerance")
+)
+
+// Sign returns the signature header value for a body sent at the given time.
+// The signature is the hex HMAC-SHA256 of "<unix timestamp>.<body>" keyed by
+// the subscription secret; binding the timestamp prevents replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac(secret, ts, body))
+}
+
+// Verify checks a signature header against the body, rejecting signatures
+// older or newer than tolerance relative to now. Receivers use it to
+// authenticate deliveries.
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if ts == "" || len(signatures) == 0 {
+		return fmt.Errorf("%w: malformed header", ErrInvalidSignature)
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrExpiredSignature
+	}
+
+	expected := mac(secret, ts, body)
+	for _, sig := range signatures {
+		got, err := hex.DecodeString(sig)
+		if err == nil && hmac.Equal(got, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// mac computes the HMAC of the signed content
+func mac(secret, ts string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
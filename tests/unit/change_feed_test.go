@@ -0,0 +1,425 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	proto "example-service/example-service/proto"
+	grpcHandler "example-service/internal/adapters/inbound/grpc"
+	"example-service/internal/application"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"net"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// publishChange stores an example event in the outbox
+func publishChange(t *testing.T, publisher external.EventPublisher, eventType string, id int64, status string) {
+	t.Helper()
+	var payload interface{}
+	switch eventType {
+	case domain.EventTypeExampleCreated:
+		payload = domain.ExampleCreatedEvent{ExampleID: id, Name: "example", Status: status}
+	case domain.EventTypeExampleUpdated:
+		payload = domain.ExampleUpdatedEvent{ExampleID: id, Name: "example", Status: status}
+	default:
+		payload = domain.ExampleDeletedEvent{ExampleID: id, Status: status}
+	}
+	if err := publisher.Publish(context.Background(), domain.NewEvent(eventType, id, payload, time.Now())); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
+
+// startWatch runs WatchExamples in the background and returns the channel
+// receiving its changes and the channel receiving its result
+func startWatch(ctx context.Context, feed *application.ChangeFeed, req *dto.WatchExamplesRequest) (<-chan *dto.ExampleChange, <-chan error) {
+	changes := make(chan *dto.ExampleChange, 100)
+	result := make(chan error, 1)
+	go func() {
+		result <- feed.WatchExamples(ctx, req, func(c *dto.ExampleChange) error {
+			changes <- c
+			return nil
+		})
+	}()
+	return changes, result
+}
+
+// nextChanges waits for n changes
+func nextChanges(t *testing.T, changes <-chan *dto.ExampleChange, n int) []*dto.ExampleChange {
+	t.Helper()
+	var got []*dto.ExampleChange
+	for len(got) < n {
+		select {
+		case c := <-changes:
+			got = append(got, c)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("got %d changes, want %d", len(got), n)
+		}
+	}
+	return got
+}
+
+// seedResumeToken returns the resume token of a change made after a watcher
+// joined the feed, so later watchers can start from a known point
+func seedResumeToken(t *testing.T, feed *application.ChangeFeed, publisher external.EventPublisher) string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := feed.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	changes, _ := startWatch(ctx, feed, &dto.WatchExamplesRequest{})
+	for i := 0; i < 100; i++ {
+		publishChange(t, publisher, domain.EventTypeExampleCreated, 1000, "active")
+		if _, err := feed.Poll(ctx); err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+		select {
+		case c := <-changes:
+			return c.ResumeToken
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Fatal("watcher never received a change")
+	return ""
+}
+
+type changeSummary struct {
+	Type   string
+	ID     int64
+	Status string
+}
+
+func summarize(changes []*dto.ExampleChange) []changeSummary {
+	summary := make([]changeSummary, len(changes))
+	for i, c := range changes {
+		summary[i] = changeSummary{c.Type, c.ExampleID, c.Status}
+	}
+	return summary
+}
+
+// TestChangeFeed_Filters tests that watchers receive the committed changes
+// matching their filters, in order
+func TestChangeFeed_Filters(t *testing.T) {
+	tests := []struct {
+		name string
+		req  dto.WatchExamplesRequest
+		want []changeSummary
+	}{
+		{
+			name: "all",
+			want: []changeSummary{
+				{dto.ChangeCreated, 1, "active"},
+				{dto.ChangeCreated, 2, "active"},
+				{dto.ChangeUpdated, 2, "inactive"},
+				{dto.ChangeDeleted, 1, "active"},
+			},
+		},
+		{
+			name: "by id",
+			req:  dto.WatchExamplesRequest{IDs: []int64{2}},
+			want: []changeSummary{{dto.ChangeCreated, 2, "active"}, {dto.ChangeUpdated, 2, "inactive"}},
+		},
+		{
+			name: "by status",
+			req:  dto.WatchExamplesRequest{Status: "inactive"},
+			want: []changeSummary{{dto.ChangeUpdated, 2, "inactive"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			outbox := &fakeOutboxRepository{}
+			publisher := application.NewOutboxPublisher(outbox)
+			feed := application.NewChangeFeed(outbox, application.ChangeFeedConfig{})
+
+			req := tt.req
+			req.ResumeToken = seedResumeToken(t, feed, publisher)
+			changes, _ := startWatch(ctx, feed, &req)
+
+			publishChange(t, publisher, domain.EventTypeExampleCreated, 1, "active")
+			publishChange(t, publisher, domain.EventTypeExampleCreated, 2, "active")
+			publishChange(t, publisher, domain.EventTypeExampleUpdated, 2, "inactive")
+			publishChange(t, publisher, domain.EventTypeExampleDeleted, 1, "active")
+			if _, err := feed.Poll(ctx); err != nil {
+				t.Fatalf("Poll() error = %v", err)
+			}
+
+			got := summarize(nextChanges(t, changes, len(tt.want)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestChangeFeed_Resume tests that a reconnecting watcher receives the
+// changes made after its resume token, then live changes
+func TestChangeFeed_Resume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outbox := &fakeOutboxRepository{}
+	publisher := application.NewOutboxPublisher(outbox)
+	feed := application.NewChangeFeed(outbox, application.ChangeFeedConfig{})
+
+	token := seedResumeToken(t, feed, publisher)
+	for id := int64(1); id <= 3; id++ {
+		publishChange(t, publisher, domain.EventTypeExampleCreated, id, "active")
+	}
+	if _, err := feed.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	// The first watcher disconnects after two changes
+	first, cancelFirst := context.WithCancel(ctx)
+	changes, _ := startWatch(first, feed, &dto.WatchExamplesRequest{ResumeToken: token})
+	seen := nextChanges(t, changes, 2)
+	cancelFirst()
+
+	publishChange(t, publisher, domain.EventTypeExampleCreated, 4, "active")
+	if _, err := feed.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	changes, _ = startWatch(ctx, feed, &dto.WatchExamplesRequest{ResumeToken: seen[1].ResumeToken})
+	got := nextChanges(t, changes, 2)
+	if got[0].ExampleID != 3 || got[1].ExampleID != 4 {
+		t.Errorf("resumed changes = %v, want examples 3 and 4", summarize(got))
+	}
+
+	err := feed.WatchExamples(ctx, &dto.WatchExamplesRequest{ResumeToken: "not-a-token"}, func(*dto.ExampleChange) error { return nil })
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("WatchExamples() with a bad token error = %v, want invalid input", err)
+	}
+}
+
+// TestChangeFeed_SlowWatcher tests that a blocked watcher holds up neither
+// the feed nor other watchers and still receives every change
+func TestChangeFeed_SlowWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outbox := &fakeOutboxRepository{}
+	publisher := application.NewOutboxPublisher(outbox)
+	feed := application.NewChangeFeed(outbox, application.ChangeFeedConfig{Buffer: 2})
+	token := seedResumeToken(t, feed, publisher)
+
+	release := make(chan struct{})
+	slow := make(chan *dto.ExampleChange, 100)
+	go feed.WatchExamples(ctx, &dto.WatchExamplesRequest{ResumeToken: token}, func(c *dto.ExampleChange) error {
+		<-release
+		slow <- c
+		return nil
+	})
+	fast, _ := startWatch(ctx, feed, &dto.WatchExamplesRequest{ResumeToken: token})
+
+	const n = 20
+	for id := int64(1); id <= n; id++ {
+		publishChange(t, publisher, domain.EventTypeExampleCreated, id, "active")
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			feed.Poll(ctx)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Poll() blocked on a slow watcher")
+		}
+	}
+	nextChanges(t, fast, n)
+
+	close(release)
+	got := nextChanges(t, slow, n)
+	for i, c := range got {
+		if c.ExampleID != int64(i+1) {
+			t.Fatalf("slow watcher changes = %v, want examples 1 to %d in order", summarize(got), n)
+		}
+	}
+	select {
+	case c := <-slow:
+		t.Errorf("unexpected extra change %+v", c)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestChangeFeed_Gap tests that the feed waits for a missing outbox id to
+// commit before moving past it
+func TestChangeFeed_Gap(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outbox := &fakeOutboxRepository{}
+	publisher := application.NewOutboxPublisher(outbox)
+	feed := application.NewChangeFeed(outbox, application.ChangeFeedConfig{GapTimeout: 50 * time.Millisecond})
+	changes, _ := startWatch(ctx, feed, &dto.WatchExamplesRequest{ResumeToken: seedResumeToken(t, feed, publisher)})
+
+	// Simulate a transaction holding the next id that has not committed yet
+	outbox.mu.Lock()
+	next := int64(len(outbox.messages) + 1)
+	outbox.messages = append(outbox.messages, &domain.OutboxMessage{
+		ID:        next + 1,
+		EventType: domain.EventTypeExampleCreated,
+		Payload:   []byte(`{"example_id":7,"status":"active"}`),
+	})
+	outbox.mu.Unlock()
+
+	if n, err := feed.Poll(ctx); err != nil || n != 0 {
+		t.Fatalf("Poll() = %d, %v, want to wait for the gap", n, err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if n, err := feed.Poll(ctx); err != nil || n != 1 {
+		t.Fatalf("Poll() = %d, %v, want to move past the gap", n, err)
+	}
+	if got := nextChanges(t, changes, 1); got[0].ExampleID != 7 {
+		t.Errorf("change = %+v, want example 7", got[0])
+	}
+}
+
+// TestChangeFeed_LateCommit tests that a change committing after the feed
+// moved past its id is still sent to the watchers, with a resume token that
+// does not replay the changes sent before it
+func TestChangeFeed_LateCommit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outbox := &fakeOutboxRepository{}
+	publisher := application.NewOutboxPublisher(outbox)
+	feed := application.NewChangeFeed(outbox, application.ChangeFeedConfig{GapTimeout: time.Millisecond})
+	changes, _ := startWatch(ctx, feed, &dto.WatchExamplesRequest{ResumeToken: seedResumeToken(t, feed, publisher)})
+
+	outbox.mu.Lock()
+	next := int64(len(outbox.messages) + 1)
+	outbox.messages = append(outbox.messages, &domain.OutboxMessage{
+		ID:        next + 1,
+		EventType: domain.EventTypeExampleCreated,
+		Payload:   []byte(`{"example_id":8,"status":"active"}`),
+	})
+	outbox.mu.Unlock()
+
+	if _, err := feed.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if n, err := feed.Poll(ctx); err != nil || n != 1 {
+		t.Fatalf("Poll() = %d, %v, want to move past the gap", n, err)
+	}
+	after := nextChanges(t, changes, 1)[0]
+	if after.ExampleID != 8 {
+		t.Fatalf("change = %+v, want example 8", after)
+	}
+
+	// The transaction holding the skipped id commits
+	outbox.mu.Lock()
+	outbox.messages = slices.Insert(outbox.messages, len(outbox.messages)-1, &domain.OutboxMessage{
+		ID:        next,
+		EventType: domain.EventTypeExampleCreated,
+		Payload:   []byte(`{"example_id":7,"status":"active"}`),
+	})
+	outbox.mu.Unlock()
+
+	if n, err := feed.Poll(ctx); err != nil || n != 1 {
+		t.Fatalf("Poll() = %d, %v, want the late change", n, err)
+	}
+	late := nextChanges(t, changes, 1)[0]
+	if late.ExampleID != 7 {
+		t.Fatalf("change = %+v, want example 7", late)
+	}
+	if late.ResumeToken != after.ResumeToken {
+		t.Errorf("late change resume token = %q, want %q", late.ResumeToken, after.ResumeToken)
+	}
+	if n, err := feed.Poll(ctx); err != nil || n != 0 {
+		t.Errorf("Poll() = %d, %v, want the late change sent once", n, err)
+	}
+}
+
+// TestChangeFeed_Stop tests that stopping the feed ends open watches
+func TestChangeFeed_Stop(t *testing.T) {
+	outbox := &fakeOutboxRepository{}
+	feed := application.NewChangeFeed(outbox, application.ChangeFeedConfig{PollInterval: time.Millisecond})
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		feed.Run(ctx)
+	}()
+
+	_, result := startWatch(context.Background(), feed, &dto.WatchExamplesRequest{})
+	stop()
+	<-done
+	select {
+	case err := <-result:
+		if !errors.Is(err, domain.ErrChangeFeedStopped) {
+			t.Errorf("WatchExamples() error = %v, want %v", err, domain.ErrChangeFeedStopped)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchExamples() did not return after the feed stopped")
+	}
+}
+
+// stubWatcher sends canned changes, then waits for the client to leave
+type stubWatcher struct {
+	req *dto.WatchExamplesRequest
+}
+
+func (w *stubWatcher) WatchExamples(ctx context.Context, req *dto.WatchExamplesRequest, fn func(*dto.ExampleChange) error) error {
+	w.req = req
+	for _, c := range []*dto.ExampleChange{
+		{Type: dto.ChangeCreated, ExampleID: 1, Name: "a", Status: "active", ResumeToken: "t1"},
+		{Type: dto.ChangeDeleted, ExampleID: 1, Status: "active", ResumeToken: "t2"},
+	} {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (w *stubWatcher) ValidateWatch(req *dto.WatchExamplesRequest) error {
+	return nil
+}
+
+// TestGRPCHandler_WatchExamples tests streaming changes over gRPC
+func TestGRPCHandler_WatchExamples(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	watcher := &stubWatcher{}
+	srv := grpc.NewServer()
+	proto.RegisterExampleServiceServer(srv, grpcHandler.NewHandler(&stubExampleService{}, watcher))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := proto.NewExampleServiceClient(conn).WatchExamples(ctx, &proto.WatchExamplesRequest{Ids: []int64{1}, ResumeToken: "t0"})
+	if err != nil {
+		t.Fatalf("WatchExamples() error = %v", err)
+	}
+
+	want := []proto.ExampleChange_Type{proto.ExampleChange_CREATED, proto.ExampleChange_DELETED}
+	for i, wantType := range want {
+		change, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if change.Type != wantType || change.Id != 1 || change.ResumeToken != "t"+string(rune('1'+i)) {
+			t.Errorf("change %d = %v, want %v for example 1", i, change, wantType)
+		}
+	}
+	if !reflect.DeepEqual(watcher.req.IDs, []int64{1}) || watcher.req.ResumeToken != "t0" {
+		t.Errorf("watch request = %+v, want ids [1] and resume token t0", watcher.req)
+	}
+}
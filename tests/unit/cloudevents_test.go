@@ -0,0 +1,80 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"example-service/pkg/cloudevents"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func sampleCloudEvent() *cloudevents.Event {
+	return &cloudevents.Event{
+		SpecVersion:     cloudevents.SpecVersion,
+		ID:              "0b6f1c4e-1d2a-4f0e-9c1b-2a3b4c5d6e7f",
+		Source:          "example-service",
+		Type:            "ExampleCreated",
+		Subject:         "42",
+		Time:            time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		DataContentType: cloudevents.JSONContentType,
+		DataSchema:      "urn:example-service:events:ExampleCreated:v1",
+		DataVersion:     1,
+		Data:            json.RawMessage(`{"ExampleID":42}`),
+	}
+}
+
+// TestCloudEvents_RoundTrip tests that both content modes preserve every attribute
+func TestCloudEvents_RoundTrip(t *testing.T) {
+	want := sampleCloudEvent()
+
+	t.Run("structured", func(t *testing.T) {
+		b, err := cloudevents.MarshalStructured(want)
+		if err != nil {
+			t.Fatalf("MarshalStructured() error = %v", err)
+		}
+		got, err := cloudevents.UnmarshalStructured(b)
+		if err != nil {
+			t.Fatalf("UnmarshalStructured() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		attrs, err := cloudevents.BinaryAttributes(want)
+		if err != nil {
+			t.Fatalf("BinaryAttributes() error = %v", err)
+		}
+		got, err := cloudevents.FromBinary(attrs, want.DataContentType, want.Data)
+		if err != nil {
+			t.Fatalf("FromBinary() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip = %+v, want %+v", got, want)
+		}
+	})
+}
+
+// TestCloudEvents_Validate tests rejection of events missing required attributes
+func TestCloudEvents_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(e *cloudevents.Event)
+	}{
+		{"wrong specversion", func(e *cloudevents.Event) { e.SpecVersion = "0.3" }},
+		{"missing id", func(e *cloudevents.Event) { e.ID = "" }},
+		{"missing source", func(e *cloudevents.Event) { e.Source = "" }},
+		{"missing type", func(e *cloudevents.Event) { e.Type = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := sampleCloudEvent()
+			tt.mutate(e)
+			if _, err := cloudevents.MarshalStructured(e); !errors.Is(err, cloudevents.ErrInvalid) {
+				t.Errorf("MarshalStructured() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
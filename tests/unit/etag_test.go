@@ -0,0 +1,37 @@
+package unit
+
+import (
+	"example-service/pkg/etag"
+	"testing"
+)
+
+// TestParseIfMatch tests parsing of If-Match headers
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantVersion int64
+		wantOK      bool
+		wantErr     bool
+	}{
+		{name: "empty matches any", header: ""},
+		{name: "star matches any", header: "*"},
+		{name: "formatted tag", header: etag.Format(3), wantVersion: 3, wantOK: true},
+		{name: "weak tag", header: `W/"7"`, wantVersion: 7, wantOK: true},
+		{name: "unquoted", header: "3", wantErr: true},
+		{name: "not a version", header: `"abc"`, wantErr: true},
+		{name: "zero version", header: `"0"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok, err := etag.ParseIfMatch(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIfMatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("ParseIfMatch() = (%d, %v), want (%d, %v)", version, ok, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
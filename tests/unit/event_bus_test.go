@@ -0,0 +1,274 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/adapters/outbound/eventbus"
+	"example-service/internal/adapters/outbound/kafka"
+	"example-service/internal/application"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// eventRecorder is a bus handler recording the events it receives
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []*domain.Event
+}
+
+func (r *eventRecorder) handle(_ context.Context, event *domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *eventRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+func newBusEvent(eventType string) *domain.Event {
+	return domain.NewEvent(eventType, 1, domain.ExampleCreatedEvent{ExampleID: 1, Name: "a"}, time.Now())
+}
+
+// TestEventBus_Publish tests fan-out, event type filters and the isolation
+// of failing and panicking subscribers
+func TestEventBus_Publish(t *testing.T) {
+	bus := eventbus.NewEventBus()
+	all, created, async := &eventRecorder{}, &eventRecorder{}, &eventRecorder{}
+	subscribers := []eventbus.Subscriber{
+		{Name: "failing", Handler: func(context.Context, *domain.Event) error { return errors.New("index unavailable") }},
+		{Name: "panicking", Handler: func(context.Context, *domain.Event) error { panic("boom") }},
+		{Name: "all", Handler: all.handle},
+		{Name: "created", EventTypes: []string{domain.EventTypeExampleCreated}, Handler: created.handle},
+		{Name: "async", Mode: eventbus.Async, Handler: async.handle},
+	}
+	for _, s := range subscribers {
+		if err := bus.Subscribe(s); err != nil {
+			t.Fatalf("Subscribe(%s) error = %v", s.Name, err)
+		}
+	}
+	if err := bus.Subscribe(eventbus.Subscriber{Name: "all", Handler: all.handle}); err == nil {
+		t.Error("Subscribe() with a duplicate name error = nil, want error")
+	}
+
+	ctx := context.Background()
+	for _, eventType := range []string{domain.EventTypeExampleCreated, domain.EventTypeExampleDeleted} {
+		err := bus.Publish(ctx, newBusEvent(eventType))
+		if err == nil || !strings.Contains(err.Error(), "index unavailable") || !strings.Contains(err.Error(), "panicked: boom") {
+			t.Errorf("Publish(%s) error = %v, want the sync subscriber failures", eventType, err)
+		}
+	}
+	if err := bus.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if all.count() != 2 || created.count() != 1 || async.count() != 2 {
+		t.Errorf("deliveries all=%d created=%d async=%d, want 2, 1 and 2", all.count(), created.count(), async.count())
+	}
+	want := map[string]external.SubscriberStats{
+		"failing":   {Name: "failing", Mode: "sync", Failed: 2},
+		"panicking": {Name: "panicking", Mode: "sync", Failed: 2, Panics: 2},
+		"all":       {Name: "all", Mode: "sync", Delivered: 2},
+		"created":   {Name: "created", Mode: "sync", Delivered: 1},
+		"async":     {Name: "async", Mode: "async", Delivered: 2},
+	}
+	for _, got := range bus.Stats() {
+		got.LastError = ""
+		if got != want[got.Name] {
+			t.Errorf("stats = %+v, want %+v", got, want[got.Name])
+		}
+	}
+	if err := bus.Publish(ctx, newBusEvent(domain.EventTypeExampleCreated)); !errors.Is(err, eventbus.ErrClosed) {
+		t.Errorf("Publish() after Close() error = %v, want %v", err, eventbus.ErrClosed)
+	}
+}
+
+// TestEventBus_AsyncQueueFull tests that a slow async subscriber never
+// blocks Publish and counts the events it missed
+func TestEventBus_AsyncQueueFull(t *testing.T) {
+	bus := eventbus.NewEventBus()
+	release := make(chan struct{})
+	err := bus.Subscribe(eventbus.Subscriber{
+		Name:      "slow",
+		Mode:      eventbus.Async,
+		QueueSize: 1,
+		Handler: func(context.Context, *domain.Event) error {
+			<-release
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			if err := bus.Publish(context.Background(), newBusEvent(domain.EventTypeExampleCreated)); err != nil {
+				t.Errorf("Publish() error = %v", err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish() blocked on a slow async subscriber")
+	}
+	close(release)
+	bus.Close()
+
+	stats := bus.Stats()[0]
+	if stats.Delivered+stats.Dropped != 5 || stats.Dropped < 3 {
+		t.Errorf("stats = %+v, want 5 events with at least 3 dropped", stats)
+	}
+}
+
+// TestEventBus_ReentrantSubscriber tests that a sync subscriber can
+// subscribe and publish a follow-up event from its handler
+func TestEventBus_ReentrantSubscriber(t *testing.T) {
+	// The bus is not closed, which would hang if Publish deadlocked
+	bus := eventbus.NewEventBus()
+	followUps := &eventRecorder{}
+	err := bus.Subscribe(eventbus.Subscriber{
+		Name:       "reentrant",
+		EventTypes: []string{domain.EventTypeExampleCreated},
+		Handler: func(ctx context.Context, event *domain.Event) error {
+			if err := bus.Subscribe(eventbus.Subscriber{Name: "late", Handler: func(context.Context, *domain.Event) error { return nil }}); err != nil {
+				return err
+			}
+			return bus.Publish(ctx, newBusEvent(domain.EventTypeExampleUpdated))
+		},
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	err = bus.Subscribe(eventbus.Subscriber{
+		Name:       "follow-ups",
+		EventTypes: []string{domain.EventTypeExampleUpdated},
+		Handler:    followUps.handle,
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- bus.Publish(context.Background(), newBusEvent(domain.EventTypeExampleCreated)) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publish() deadlocked on a subscriber using the bus")
+	}
+	if followUps.count() != 1 {
+		t.Errorf("follow-up subscriber got %d events, want 1", followUps.count())
+	}
+}
+
+// TestEventBus_WithKafka tests that one Publish reaches both Kafka and the
+// bus subscribers
+func TestEventBus_WithKafka(t *testing.T) {
+	cluster := newFakeKafka(t, "example-service.example-created")
+	kafkaPublisher, err := kafka.NewEventPublisher(kafkaTestConfig(cluster), newTestEncoder(t, envelope.FormatJSON))
+	if err != nil {
+		t.Fatalf("NewEventPublisher() error = %v", err)
+	}
+	bus := eventbus.NewEventBus()
+	recorder := &eventRecorder{}
+	if err := bus.Subscribe(eventbus.Subscriber{Name: "cache", Handler: recorder.handle}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	publisher := application.NewMultiPublisher(kafkaPublisher, bus)
+	defer publisher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	event := newBusEvent(domain.EventTypeExampleCreated)
+	if err := publisher.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if recorder.count() != 1 || recorder.events[0].ID != event.ID {
+		t.Errorf("bus subscriber got %d events, want event %s", recorder.count(), event.ID)
+	}
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics("example-service.example-created"),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create consumer: %v", err)
+	}
+	defer consumer.Close()
+	for {
+		fetches := consumer.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			t.Fatal("timed out waiting for the Kafka record")
+		}
+		if fetches.NumRecords() > 0 {
+			if id := recordEvent(t, fetches.Records()[0]).ID; id != event.ID {
+				t.Errorf("Kafka record event id = %s, want %s", id, event.ID)
+			}
+			return
+		}
+	}
+}
+
+// TestEventBus_BestEffortBehindRelay tests that a failing sync subscriber
+// neither makes the relay retry an event the broker accepted nor goes
+// unnoticed in the publish stats
+func TestEventBus_BestEffortBehindRelay(t *testing.T) {
+	ctx := context.Background()
+	outbox := &fakeOutboxRepository{}
+	if err := application.NewOutboxPublisher(outbox).Publish(ctx, newBusEvent(domain.EventTypeExampleCreated)); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	bus := eventbus.NewEventBus()
+	defer bus.Close()
+	err := bus.Subscribe(eventbus.Subscriber{
+		Name: "failing",
+		Handler: func(context.Context, *domain.Event) error {
+			return errors.New("index unavailable")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	broker := &recordingPublisher{}
+	metrics := application.NewPublishMetrics()
+	relay := application.NewOutboxRelay(outbox, application.NewMultiPublisher(broker, bus.BestEffort()), application.OutboxRelayConfig{
+		Metrics: metrics,
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := relay.RelayPending(ctx); err != nil {
+			t.Fatalf("RelayPending() error = %v", err)
+		}
+	}
+	if len(broker.published) != 1 {
+		t.Errorf("broker got %d events, want the event once", len(broker.published))
+	}
+
+	stats, err := application.NewEventAdminService(outbox, metrics, bus).GetPublishStats(ctx)
+	if err != nil {
+		t.Fatalf("GetPublishStats() error = %v", err)
+	}
+	if stats.Published != 1 || stats.Failed != 0 {
+		t.Errorf("stats = %+v, want 1 published and none failed", stats)
+	}
+	if len(stats.Subscribers) != 1 || stats.Subscribers[0].Failed != 1 || stats.Subscribers[0].LastError == "" {
+		t.Errorf("subscriber stats = %+v, want one failure of the failing subscriber", stats.Subscribers)
+	}
+}
@@ -0,0 +1,344 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	inboundKafka "example-service/internal/adapters/inbound/kafka"
+	"example-service/internal/adapters/outbound/postgres"
+	"example-service/internal/application"
+	"example-service/internal/application/dto"
+	"example-service/internal/config"
+	"example-service/internal/domain"
+	"example-service/pkg/cloudevents"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// fakeProcessedEvents keeps processed event markers in memory
+type fakeProcessedEvents struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newFakeProcessedEvents() *fakeProcessedEvents {
+	return &fakeProcessedEvents{seen: make(map[string]bool)}
+}
+
+func (r *fakeProcessedEvents) IsProcessed(ctx context.Context, consumer, eventID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seen[consumer+"|"+eventID], nil
+}
+
+func (r *fakeProcessedEvents) MarkProcessed(ctx context.Context, event *domain.ProcessedEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := event.Consumer + "|" + event.EventID
+	if r.seen[key] {
+		return domain.ErrEventProcessed
+	}
+	r.seen[key] = true
+	return nil
+}
+
+type accountClosed struct {
+	AccountID string `json:"account_id"`
+}
+
+// TestEventDispatcher tests typed dispatch, idempotency and permanent errors
+func TestEventDispatcher(t *testing.T) {
+	ctx := context.Background()
+	dispatcher := application.NewEventDispatcher("test", newFakeProcessedEvents(), nil)
+
+	var closed []string
+	failures := 1
+	application.Handle(dispatcher, "AccountClosed", func(ctx context.Context, event *domain.InboundEvent, payload accountClosed) error {
+		if failures > 0 {
+			failures--
+			return errors.New("database unavailable")
+		}
+		closed = append(closed, payload.AccountID)
+		return nil
+	})
+
+	event := &domain.InboundEvent{ID: "1", Source: "accounts", Type: "AccountClosed", Data: []byte(`{"account_id":"acc-1"}`)}
+
+	if err := dispatcher.HandleEvent(ctx, event); err == nil {
+		t.Fatal("HandleEvent() error = nil, want the handler error")
+	}
+	for i := 0; i < 2; i++ {
+		if err := dispatcher.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("HandleEvent() error = %v", err)
+		}
+	}
+	if len(closed) != 1 || closed[0] != "acc-1" {
+		t.Errorf("handled accounts = %v, want [acc-1] exactly once", closed)
+	}
+
+	// The same id from another source is a different event
+	other := *event
+	other.Source = "billing"
+	if err := dispatcher.HandleEvent(ctx, &other); err != nil || len(closed) != 2 {
+		t.Errorf("HandleEvent(other source) error = %v, handled %d, want handled again", err, len(closed))
+	}
+
+	tests := []struct {
+		name          string
+		event         *domain.InboundEvent
+		wantPermanent bool
+	}{
+		{"unregistered type is ignored", &domain.InboundEvent{ID: "2", Type: "AccountOpened"}, false},
+		{"undecodable data", &domain.InboundEvent{ID: "3", Type: "AccountClosed", Data: []byte("{")}, true},
+		{"missing id", &domain.InboundEvent{Type: "AccountClosed", Data: []byte("{}")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := dispatcher.HandleEvent(ctx, tt.event)
+			if got := errors.Is(err, domain.ErrInvalidInput); got != tt.wantPermanent {
+				t.Errorf("HandleEvent() error = %v, want permanent %v", err, tt.wantPermanent)
+			}
+		})
+	}
+}
+
+// produceCloudEvent writes an event in binary mode to topic. All test records
+// share a key so they are consumed in order from one partition.
+func produceCloudEvent(t *testing.T, client *kgo.Client, topic string, ce *cloudevents.Event) {
+	t.Helper()
+	attrs, err := cloudevents.BinaryAttributes(ce)
+	if err != nil {
+		t.Fatalf("BinaryAttributes() error = %v", err)
+	}
+	record := &kgo.Record{Topic: topic, Key: []byte("accounts"), Value: ce.Data}
+	record.Headers = append(record.Headers, kgo.RecordHeader{Key: "content-type", Value: []byte(cloudevents.JSONContentType)})
+	for name, value := range attrs {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: "ce_" + name, Value: []byte(value)})
+	}
+	if err := client.ProduceSync(context.Background(), record).FirstErr(); err != nil {
+		t.Fatalf("failed to produce: %v", err)
+	}
+}
+
+// TestKafkaConsumer tests that duplicates are skipped and failing events are
+// retried and then dead-lettered
+func TestKafkaConsumer(t *testing.T) {
+	cluster := newFakeKafka(t, "accounts", "example-service.dlt")
+	brokers := cluster.ListenAddrs()
+
+	var (
+		mu       sync.Mutex
+		handled  []string
+		attempts int
+	)
+	dispatcher := application.NewEventDispatcher("example-service", newFakeProcessedEvents(), nil)
+	application.Handle(dispatcher, "AccountClosed", func(ctx context.Context, event *domain.InboundEvent, payload accountClosed) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if payload.AccountID == "poison" {
+			attempts++
+			return errors.New("cannot close")
+		}
+		handled = append(handled, payload.AccountID)
+		return nil
+	})
+
+	producer, err := kgo.NewClient(kgo.SeedBrokers(brokers...))
+	if err != nil {
+		t.Fatalf("failed to create producer: %v", err)
+	}
+	defer producer.Close()
+
+	for _, e := range []struct{ id, account string }{{"1", "acc-1"}, {"1", "acc-1"}, {"2", "poison"}, {"3", "acc-3"}} {
+		produceCloudEvent(t, producer, "accounts", &cloudevents.Event{
+			SpecVersion: cloudevents.SpecVersion,
+			ID:          e.id,
+			Source:      "accounts-service",
+			Type:        "AccountClosed",
+			Data:        []byte(`{"account_id":"` + e.account + `"}`),
+		})
+	}
+	if err := producer.ProduceSync(context.Background(), &kgo.Record{Topic: "accounts", Key: []byte("accounts"), Value: []byte("not an event")}).FirstErr(); err != nil {
+		t.Fatalf("failed to produce: %v", err)
+	}
+
+	consumer, err := inboundKafka.NewConsumer(config.KafkaConfig{
+		Brokers: brokers,
+		Consumer: config.KafkaConsumerConfig{
+			Topics:          []string{"accounts"},
+			GroupID:         "example-service",
+			DeadLetterTopic: "example-service.dlt",
+			MaxAttempts:     3,
+			RetryBackoff:    time.Millisecond,
+		},
+	}, dispatcher)
+	if err != nil {
+		t.Fatalf("NewConsumer() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		consumer.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+		consumer.Close()
+	}()
+
+	dlt, err := kgo.NewClient(
+		kgo.SeedBrokers(brokers...),
+		kgo.ConsumeTopics("example-service.dlt"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create dead-letter consumer: %v", err)
+	}
+	defer dlt.Close()
+
+	pollCtx, pollCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer pollCancel()
+	var deadLettered []*kgo.Record
+	for len(deadLettered) < 2 {
+		fetches := dlt.PollFetches(pollCtx)
+		if pollCtx.Err() != nil {
+			t.Fatalf("timed out waiting for dead letters, got %d", len(deadLettered))
+		}
+		deadLettered = append(deadLettered, fetches.Records()...)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 3 {
+		t.Errorf("poison event attempts = %d, want 3", attempts)
+	}
+	if len(handled) != 2 || handled[0] != "acc-1" || handled[1] != "acc-3" {
+		t.Errorf("handled = %v, want [acc-1 acc-3]", handled)
+	}
+	for _, r := range deadLettered {
+		var source string
+		for _, h := range r.Headers {
+			if h.Key == "dlt-topic" {
+				source = string(h.Value)
+			}
+		}
+		if source != "accounts" {
+			t.Errorf("dead letter dlt-topic = %q, want accounts", source)
+		}
+	}
+}
+
+// TestAccountClosed tests that an AccountClosed event consumed from Kafka
+// deactivates the examples of the account once, through the handlers the
+// server registers
+func TestAccountClosed(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+	transactor := postgres.NewTransactor(db)
+	exampleService := application.NewExampleService(
+		postgres.NewExampleRepository(db),
+		application.NewOutboxPublisher(postgres.NewOutboxRepository(db)),
+		transactor,
+	)
+	dispatcher := application.NewEventDispatcher("example-service", postgres.NewProcessedEventRepository(db), transactor)
+	application.RegisterEventHandlers(dispatcher, exampleService)
+
+	ids := make(map[string]int64)
+	for _, e := range []struct{ name, account string }{{"a", "acc-1"}, {"b", "acc-1"}, {"c", "acc-2"}, {"d", ""}} {
+		resp, err := exampleService.CreateExample(ctx, &dto.CreateExampleRequest{Name: e.name, AccountID: e.account})
+		if err != nil {
+			t.Fatalf("CreateExample() error = %v", err)
+		}
+		if resp.AccountID != e.account {
+			t.Errorf("CreateExample() account id = %q, want %q", resp.AccountID, e.account)
+		}
+		ids[e.name] = resp.ID
+	}
+
+	// An event without an account id is a permanent failure
+	err := dispatcher.HandleEvent(ctx, &domain.InboundEvent{ID: "0", Source: "accounts-service", Type: domain.EventTypeAccountClosed, Data: []byte("{}")})
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("HandleEvent() without an account id error = %v, want ErrInvalidInput", err)
+	}
+
+	cluster := newFakeKafka(t, "accounts", "example-service.dlt")
+	brokers := cluster.ListenAddrs()
+	producer, err := kgo.NewClient(kgo.SeedBrokers(brokers...))
+	if err != nil {
+		t.Fatalf("failed to create producer: %v", err)
+	}
+	defer producer.Close()
+	for _, e := range []struct{ id, account string }{{"1", "acc-1"}, {"1", "acc-1"}, {"2", "acc-3"}} {
+		produceCloudEvent(t, producer, "accounts", &cloudevents.Event{
+			SpecVersion: cloudevents.SpecVersion,
+			ID:          e.id,
+			Source:      "accounts-service",
+			Type:        domain.EventTypeAccountClosed,
+			Data:        []byte(`{"account_id":"` + e.account + `"}`),
+		})
+	}
+
+	consumer, err := inboundKafka.NewConsumer(config.KafkaConfig{
+		Brokers: brokers,
+		Consumer: config.KafkaConsumerConfig{
+			Topics:          []string{"accounts"},
+			GroupID:         "example-service",
+			DeadLetterTopic: "example-service.dlt",
+			MaxAttempts:     3,
+			RetryBackoff:    time.Millisecond,
+		},
+	}, dispatcher)
+	if err != nil {
+		t.Fatalf("NewConsumer() error = %v", err)
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		consumer.Run(runCtx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+		consumer.Close()
+	}()
+
+	// The events are consumed in order, so the last one being processed
+	// means the duplicate before it was skipped
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var processed int64
+		if err := db.Model(&domain.ProcessedEvent{}).Count(&processed).Error; err != nil {
+			t.Fatalf("failed to count processed events: %v", err)
+		}
+		if processed == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the events, %d processed", processed)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	want := map[string]string{"a": "inactive", "b": "inactive", "c": "active", "d": "active"}
+	for name, status := range want {
+		resp, err := exampleService.GetExample(ctx, ids[name])
+		if err != nil {
+			t.Fatalf("GetExample() error = %v", err)
+		}
+		if resp.Status != status {
+			t.Errorf("example %s status = %s, want %s", name, resp.Status, status)
+		}
+	}
+
+	var updates int64
+	if err := db.Model(&domain.OutboxMessage{}).Where("event_type = ?", domain.EventTypeExampleUpdated).Count(&updates).Error; err != nil {
+		t.Fatalf("failed to count outbox messages: %v", err)
+	}
+	if updates != 2 {
+		t.Errorf("ExampleUpdated events = %d, want 2", updates)
+	}
+}
@@ -0,0 +1,223 @@
+package unit
+
+import (
+	"errors"
+	eventspb "example-service/example-service/proto"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/domain"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// TestEncoder_Formats tests that payloads round trip through both encodings
+func TestEncoder_Formats(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	event := domain.NewEvent(domain.EventTypeExampleCreated, 42,
+		domain.ExampleCreatedEvent{ExampleID: 42, Name: "widget", Timestamp: now}, now)
+
+	tests := []struct {
+		format      string
+		contentType string
+	}{
+		{envelope.FormatJSON, "application/json"},
+		{envelope.FormatProtobuf, "application/protobuf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			encoder := newTestEncoder(t, tt.format)
+			ce, err := encoder.Encode(event)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if ce.DataContentType != tt.contentType {
+				t.Errorf("datacontenttype = %s, want %s", ce.DataContentType, tt.contentType)
+			}
+
+			msg, err := encoder.Decode(ce)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			got, ok := msg.(*eventspb.ExampleCreated)
+			if !ok {
+				t.Fatalf("Decode() = %T, want *ExampleCreated", msg)
+			}
+			if got.GetExampleId() != 42 || got.GetName() != "widget" || !got.GetTimestamp().AsTime().Equal(now) {
+				t.Errorf("Decode() = %v, want the original payload", got)
+			}
+		})
+	}
+}
+
+// TestEncoder_UnknownSchema tests that unregistered event versions are rejected
+func TestEncoder_UnknownSchema(t *testing.T) {
+	encoder := newTestEncoder(t, envelope.FormatJSON)
+	event := domain.NewEvent(domain.EventTypeExampleCreated, 1, nil, time.Now())
+	event.Version = domain.ExampleEventVersion + 1
+
+	if _, err := encoder.Encode(event); !errors.Is(err, envelope.ErrUnknownSchema) {
+		t.Errorf("Encode() error = %v, want ErrUnknownSchema", err)
+	}
+}
+
+// TestEncoder_Versions tests that each payload version is encoded as its own
+// message and that a field added in version 2 is refused in version 1
+func TestEncoder_Versions(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	payload := domain.ExampleCreatedEvent{ExampleID: 42, Name: "widget", Status: "active", Timestamp: now}
+
+	tests := []struct {
+		name    string
+		event   *domain.Event
+		want    proto.Message
+		schema  string
+		wantErr bool
+	}{
+		{"v1", domain.NewEvent(domain.EventTypeExampleCreated, 42, domain.ExampleCreatedEvent{ExampleID: 42, Name: "widget", Timestamp: now}, now),
+			&eventspb.ExampleCreated{}, "urn:example-service:events:ExampleCreated:v1", false},
+		{"v2", domain.NewExampleEvent(domain.EventTypeExampleCreated, 42, payload, now),
+			&eventspb.ExampleCreatedV2{}, "urn:example-service:events:ExampleCreated:v2", false},
+		{"status in v1", domain.NewEvent(domain.EventTypeExampleCreated, 42, payload, now), nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder := newTestEncoder(t, envelope.FormatJSON)
+			ce, err := encoder.Encode(tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if ce.DataSchema != tt.schema || ce.DataVersion != tt.event.Version {
+				t.Errorf("dataschema = %s (v%d), want %s (v%d)", ce.DataSchema, ce.DataVersion, tt.schema, tt.event.Version)
+			}
+
+			msg, err := encoder.Decode(ce)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if msg.ProtoReflect().Descriptor().FullName() != tt.want.ProtoReflect().Descriptor().FullName() {
+				t.Errorf("Decode() = %T, want %T", msg, tt.want)
+			}
+			if v2, ok := msg.(*eventspb.ExampleCreatedV2); ok && v2.GetStatus() != "active" {
+				t.Errorf("status = %q, want active", v2.GetStatus())
+			}
+		})
+	}
+}
+
+// testMessage builds a message descriptor for compatibility tests
+func testMessage(t *testing.T, pkg string, fields []*descriptorpb.FieldDescriptorProto, reserved []int32, reservedNames ...string) protoreflect.MessageDescriptor {
+	t.Helper()
+	msg := &descriptorpb.DescriptorProto{
+		Name:         proto.String("Event"),
+		Field:        fields,
+		ReservedName: reservedNames,
+	}
+	for _, n := range reserved {
+		msg.ReservedRange = append(msg.ReservedRange, &descriptorpb.DescriptorProto_ReservedRange{
+			Start: proto.Int32(n),
+			End:   proto.Int32(n + 1),
+		})
+	}
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String(pkg + ".proto"),
+		Package:     proto.String(pkg),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{msg},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to build descriptor: %v", err)
+	}
+	return file.Messages().Get(0)
+}
+
+func testField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     typ.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+}
+
+// TestCheckCompatible tests detection of breaking schema changes
+func TestCheckCompatible(t *testing.T) {
+	int64Type := descriptorpb.FieldDescriptorProto_TYPE_INT64
+	stringType := descriptorpb.FieldDescriptorProto_TYPE_STRING
+
+	v1 := []*descriptorpb.FieldDescriptorProto{
+		testField("example_id", 1, int64Type),
+		testField("name", 2, stringType),
+	}
+
+	idOnly := []*descriptorpb.FieldDescriptorProto{testField("example_id", 1, int64Type)}
+
+	tests := []struct {
+		name          string
+		fields        []*descriptorpb.FieldDescriptorProto
+		reserved      []int32
+		reservedNames []string
+		wantErr       bool
+	}{
+		{"unchanged", v1, nil, nil, false},
+		{"field added", append(v1[:2:2], testField("status", 3, stringType)), nil, nil, false},
+		{"field removed and reserved", idOnly, []int32{2}, []string{"name"}, false},
+		{"field renamed", []*descriptorpb.FieldDescriptorProto{testField("example_id", 1, int64Type), testField("title", 2, stringType)}, nil, nil, true},
+		{"type changed", []*descriptorpb.FieldDescriptorProto{testField("example_id", 1, stringType), testField("name", 2, stringType)}, nil, nil, true},
+		{"field removed", idOnly, nil, nil, true},
+		{"field removed without reserving name", idOnly, []int32{2}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			older := testMessage(t, "compat.v1", v1, nil)
+			newer := testMessage(t, "compat.v2", tt.fields, tt.reserved, tt.reservedNames...)
+			err := envelope.CheckCompatible(older, newer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckCompatible() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, envelope.ErrIncompatible) {
+				t.Errorf("CheckCompatible() error = %v, want ErrIncompatible", err)
+			}
+		})
+	}
+}
+
+// TestRegistry_Check tests that a breaking new version fails the registry check
+func TestRegistry_Check(t *testing.T) {
+	if err := envelope.DefaultRegistry().Check(); err != nil {
+		t.Fatalf("DefaultRegistry().Check() error = %v", err)
+	}
+
+	v1 := testMessage(t, "registry.v1", []*descriptorpb.FieldDescriptorProto{
+		testField("example_id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+	}, nil)
+	v2 := testMessage(t, "registry.v2", []*descriptorpb.FieldDescriptorProto{
+		testField("example_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+	}, nil)
+
+	registry := envelope.NewRegistry()
+	if err := registry.Register("ExampleCreated", 1, dynamicpb.NewMessage(v1)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := registry.Register("ExampleCreated", 2, dynamicpb.NewMessage(v2)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := registry.Register("ExampleCreated", 2, dynamicpb.NewMessage(v2)); err == nil {
+		t.Error("Register() of a duplicate version succeeded, want error")
+	}
+
+	if err := registry.Check(); !errors.Is(err, envelope.ErrIncompatible) {
+		t.Errorf("Check() error = %v, want ErrIncompatible", err)
+	}
+	if _, err := envelope.NewEncoder(envelope.Config{Registry: registry}); err == nil {
+		t.Error("NewEncoder() with an incompatible registry succeeded, want error")
+	}
+}
@@ -0,0 +1,57 @@
+package unit
+
+import (
+	"context"
+	"example-service/internal/adapters/outbound/memory"
+	"example-service/internal/adapters/outbound/postgres"
+	"example-service/internal/database"
+	"example-service/internal/ports/repositories"
+	"example-service/internal/ports/repositories/repositorytest"
+	"os"
+	"testing"
+)
+
+// TestExampleRepositoryConformance_Memory runs the repository conformance
+// suite against the in-memory adapter
+func TestExampleRepositoryConformance_Memory(t *testing.T) {
+	repositorytest.TestExampleRepository(t, func(t *testing.T) repositories.ExampleRepository {
+		return memory.NewExampleRepository(memory.NewStore(memory.Options{}))
+	})
+}
+
+// TestExampleRepositoryConformance_SQLite runs the repository conformance
+// suite against the GORM adapter on SQLite
+func TestExampleRepositoryConformance_SQLite(t *testing.T) {
+	repositorytest.TestExampleRepository(t, func(t *testing.T) repositories.ExampleRepository {
+		return postgres.NewExampleRepository(newSQLiteDB(t))
+	})
+}
+
+// TestExampleRepositoryConformance_Postgres runs the repository conformance
+// suite against the GORM adapter on the PostgreSQL database in
+// TEST_DATABASE_URL. Its examples table is emptied for every subtest.
+func TestExampleRepositoryConformance_Postgres(t *testing.T) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := database.InitGORM(url)
+	if err != nil {
+		t.Fatalf("InitGORM() error = %v", err)
+	}
+	t.Cleanup(func() { database.Close(db) })
+	migrator, err := database.NewMigrator(db, database.MigrateOptions{})
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	repositorytest.TestExampleRepository(t, func(t *testing.T) repositories.ExampleRepository {
+		if err := db.Exec("TRUNCATE examples RESTART IDENTITY").Error; err != nil {
+			t.Fatalf("failed to empty the examples table: %v", err)
+		}
+		return postgres.NewExampleRepository(db)
+	})
+}
@@ -0,0 +1,250 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/application"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"testing"
+)
+
+// fakeExampleRepository is a slice-backed repository ordered by id
+type fakeExampleRepository struct {
+	examples []*domain.Example
+}
+
+func (r *fakeExampleRepository) Create(ctx context.Context, example *domain.Example) error {
+	if r.nameTaken(example.Name, 0) {
+		return domain.ErrExampleAlreadyExists
+	}
+	example.ID = int64(len(r.examples) + 1)
+	r.examples = append(r.examples, example)
+	return nil
+}
+
+func (r *fakeExampleRepository) FindByID(ctx context.Context, id int64) (*domain.Example, error) {
+	for _, e := range r.examples {
+		if e.ID == id {
+			found := *e
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeExampleRepository) List(ctx context.Context, query repositories.ExampleQuery) ([]*domain.Example, error) {
+	var page []*domain.Example
+	for _, e := range r.examples {
+		if query.Filter.Status != "" && e.Status != query.Filter.Status {
+			continue
+		}
+		if query.After != nil && e.ID <= query.After.ID {
+			continue
+		}
+		if len(page) == query.Limit {
+			break
+		}
+		page = append(page, e)
+	}
+	return page, nil
+}
+
+func (r *fakeExampleRepository) Count(ctx context.Context, filter repositories.ExampleFilter) (int64, error) {
+	return int64(len(r.examples)), nil
+}
+
+func (r *fakeExampleRepository) Update(ctx context.Context, example *domain.Example) error {
+	if r.nameTaken(example.Name, example.ID) {
+		return domain.ErrExampleAlreadyExists
+	}
+	for i, e := range r.examples {
+		if e.ID == example.ID {
+			if e.Version != example.Version {
+				return domain.ErrVersionConflict
+			}
+			updated := *example
+			updated.Version++
+			r.examples[i] = &updated
+			example.Version++
+			return nil
+		}
+	}
+	return domain.ErrVersionConflict
+}
+
+// nameTaken reports whether another example already uses name
+func (r *fakeExampleRepository) nameTaken(name string, exceptID int64) bool {
+	for _, e := range r.examples {
+		if e.Name == name && e.ID != exceptID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakeExampleRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (r *fakeExampleRepository) Exists(ctx context.Context, name string) (bool, error) {
+	return false, nil
+}
+
+// TestExampleService_ListExamplesPagination tests that page tokens walk every example once
+func TestExampleService_ListExamplesPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeExampleRepository{}
+	for i := 0; i < 5; i++ {
+		repo.Create(ctx, &domain.Example{Name: fmt.Sprintf("example-%d", i), Status: "active"})
+	}
+	service := application.NewExampleService(repo, nil, nil)
+
+	var ids []int64
+	req := &dto.ListExamplesRequest{PageSize: 2, IncludeTotal: true}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		resp, err := service.ListExamples(ctx, req)
+		if err != nil {
+			t.Fatalf("ListExamples() error = %v", err)
+		}
+		if resp.TotalCount == nil || *resp.TotalCount != 5 {
+			t.Errorf("TotalCount = %v, want 5", resp.TotalCount)
+		}
+		for _, ex := range resp.Examples {
+			ids = append(ids, ex.ID)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if len(ids) != 5 {
+		t.Fatalf("got ids %v, want 5 examples", ids)
+	}
+	for i, id := range ids {
+		if id != int64(i+1) {
+			t.Errorf("ids = %v, want ascending 1..5", ids)
+			break
+		}
+	}
+}
+
+// TestExampleService_ListExamplesPageSizeCap tests that oversized pages are
+// capped rather than rejected
+func TestExampleService_ListExamplesPageSizeCap(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeExampleRepository{}
+	for i := 0; i < 150; i++ {
+		repo.Create(ctx, &domain.Example{Name: fmt.Sprintf("example-%d", i), Status: "active"})
+	}
+	service := application.NewExampleService(repo, nil, nil)
+
+	resp, err := service.ListExamples(ctx, &dto.ListExamplesRequest{PageSize: 1000})
+	if err != nil {
+		t.Fatalf("ListExamples() error = %v", err)
+	}
+	if len(resp.Examples) != 100 {
+		t.Errorf("got %d examples, want 100", len(resp.Examples))
+	}
+	if resp.NextPageToken == "" {
+		t.Error("NextPageToken is empty, want a token for the remaining examples")
+	}
+}
+
+// TestExampleService_ListExamplesInvalidToken tests that tokens are bound to their query
+func TestExampleService_ListExamplesInvalidToken(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeExampleRepository{}
+	for i := 0; i < 3; i++ {
+		repo.Create(ctx, &domain.Example{Name: fmt.Sprintf("example-%d", i), Status: "active"})
+	}
+	service := application.NewExampleService(repo, nil, nil)
+
+	resp, err := service.ListExamples(ctx, &dto.ListExamplesRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListExamples() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *dto.ListExamplesRequest
+	}{
+		{
+			name: "garbage token",
+			req:  &dto.ListExamplesRequest{PageToken: "not-a-token"},
+		},
+		{
+			name: "token from a different filter",
+			req:  &dto.ListExamplesRequest{PageToken: resp.NextPageToken, Status: "inactive"},
+		},
+		{
+			name: "unknown order_by",
+			req:  &dto.ListExamplesRequest{OrderBy: "status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ListExamples(ctx, tt.req)
+			if !errors.Is(err, domain.ErrInvalidInput) {
+				t.Errorf("error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+// TestExampleService_UpdateExampleVersion tests optimistic concurrency on updates
+func TestExampleService_UpdateExampleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeExampleRepository{}
+	service := application.NewExampleService(repo, nil, nil)
+
+	created, err := service.CreateExample(ctx, &dto.CreateExampleRequest{Name: "example"})
+	if err != nil {
+		t.Fatalf("CreateExample() error = %v", err)
+	}
+	if created.Version != 1 {
+		t.Fatalf("Version = %d, want 1", created.Version)
+	}
+
+	stale := created.Version
+	updated, err := service.UpdateExample(ctx, created.ID, &dto.UpdateExampleRequest{Name: "renamed", ExpectedVersion: &stale})
+	if err != nil {
+		t.Fatalf("UpdateExample() error = %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("Version = %d, want 2", updated.Version)
+	}
+
+	_, err = service.UpdateExample(ctx, created.ID, &dto.UpdateExampleRequest{Name: "again", ExpectedVersion: &stale})
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("error = %v, want ErrVersionConflict", err)
+	}
+}
+
+// TestExampleService_UniqueNames tests that duplicate names are rejected on create and rename
+func TestExampleService_UniqueNames(t *testing.T) {
+	ctx := context.Background()
+	service := application.NewExampleService(&fakeExampleRepository{}, nil, nil)
+
+	if _, err := service.CreateExample(ctx, &dto.CreateExampleRequest{Name: "first"}); err != nil {
+		t.Fatalf("CreateExample() error = %v", err)
+	}
+	second, err := service.CreateExample(ctx, &dto.CreateExampleRequest{Name: "second"})
+	if err != nil {
+		t.Fatalf("CreateExample() error = %v", err)
+	}
+
+	if _, err := service.CreateExample(ctx, &dto.CreateExampleRequest{Name: "first"}); !errors.Is(err, domain.ErrExampleAlreadyExists) {
+		t.Errorf("create duplicate: error = %v, want ErrExampleAlreadyExists", err)
+	}
+	if _, err := service.UpdateExample(ctx, second.ID, &dto.UpdateExampleRequest{Name: "first"}); !errors.Is(err, domain.ErrExampleAlreadyExists) {
+		t.Errorf("rename to duplicate: error = %v, want ErrExampleAlreadyExists", err)
+	}
+}
@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	proto "example-service/example-service/proto"
+	"example-service/internal/adapters/inbound/gateway"
+	grpcHandler "example-service/internal/adapters/inbound/grpc"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// stubExampleService is a canned services.ExampleService for transport tests
+type stubExampleService struct{}
+
+func (s *stubExampleService) CreateExample(ctx context.Context, req *dto.CreateExampleRequest) (*dto.ExampleResponse, error) {
+	return &dto.ExampleResponse{ID: 1, Name: req.Name, Status: "active"}, nil
+}
+
+func (s *stubExampleService) GetExample(ctx context.Context, id int64) (*dto.ExampleResponse, error) {
+	if id != 1 {
+		return nil, domain.ErrExampleNotFound
+	}
+	return &dto.ExampleResponse{ID: 1, Name: "first", Status: "active", CreatedAt: "2024-01-01T00:00:00Z"}, nil
+}
+
+func (s *stubExampleService) ListExamples(ctx context.Context, req *dto.ListExamplesRequest) (*dto.ListExamplesResponse, error) {
+	return &dto.ListExamplesResponse{}, nil
+}
+
+func (s *stubExampleService) UpdateExample(ctx context.Context, id int64, req *dto.UpdateExampleRequest) (*dto.ExampleResponse, error) {
+	return nil, domain.ErrExampleNotFound
+}
+
+func (s *stubExampleService) DeleteExample(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (s *stubExampleService) DeactivateAccountExamples(ctx context.Context, accountID string) (int, error) {
+	return 0, nil
+}
+
+// newGatewayServer starts a gRPC server backed by the stub service and
+// returns a gateway handler proxying to it
+func newGatewayServer(t *testing.T) http.Handler {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	proto.RegisterExampleServiceServer(srv, grpcHandler.NewHandler(&stubExampleService{}, nil))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	gw, err := gateway.NewHandler(context.Background(), lis.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to create gateway: %v", err)
+	}
+	t.Cleanup(func() { gw.Close() })
+	return gw
+}
+
+// TestGateway_Routes tests that REST calls are proxied to the gRPC handler
+func TestGateway_Routes(t *testing.T) {
+	gw := newGatewayServer(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantField  string
+	}{
+		{
+			name:       "create returns 201",
+			method:     http.MethodPost,
+			path:       "/api/v1/examples",
+			body:       `{"name":"new"}`,
+			wantStatus: http.StatusCreated,
+			wantField:  "name",
+		},
+		{
+			name:       "get uses snake_case fields",
+			method:     http.MethodGet,
+			path:       "/api/v1/examples/1",
+			wantStatus: http.StatusOK,
+			wantField:  "created_at",
+		},
+		{
+			name:       "missing example returns 404",
+			method:     http.MethodGet,
+			path:       "/api/v1/examples/2",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			gw.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantField == "" {
+				return
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON body: %v", err)
+			}
+			if _, ok := body[tt.wantField]; !ok {
+				t.Errorf("expected field %q in %s", tt.wantField, rec.Body.String())
+			}
+		})
+	}
+}
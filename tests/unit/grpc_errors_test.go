@@ -0,0 +1,116 @@
+package unit
+
+import (
+	"context"
+	proto "example-service/example-service/proto"
+	grpcHandler "example-service/internal/adapters/inbound/grpc"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	apperrors "example-service/pkg/errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingExampleService is a stub service whose GetExample fails with err
+type failingExampleService struct {
+	stubExampleService
+	err error
+}
+
+func (s *failingExampleService) GetExample(ctx context.Context, id int64) (*dto.ExampleResponse, error) {
+	return nil, s.err
+}
+
+// TestGRPCHandler_ErrorDetails tests that errors carry google.rpc details
+func TestGRPCHandler_ErrorDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int64
+		err        error
+		wantCode   codes.Code
+		wantReason apperrors.ErrorCode
+		wantField  string
+		wantRetry  bool
+	}{
+		{
+			name:       "not found",
+			id:         2,
+			err:        domain.ErrExampleNotFound,
+			wantCode:   codes.NotFound,
+			wantReason: apperrors.CodeNotFound,
+		},
+		{
+			name:       "wrapped not found",
+			id:         2,
+			err:        fmt.Errorf("failed to find example: %w", domain.ErrExampleNotFound),
+			wantCode:   codes.NotFound,
+			wantReason: apperrors.CodeNotFound,
+		},
+		{
+			name:       "missing id",
+			id:         0,
+			wantCode:   codes.InvalidArgument,
+			wantReason: apperrors.CodeInvalidInput,
+			wantField:  "id",
+		},
+		{
+			name:       "timeout",
+			id:         2,
+			err:        fmt.Errorf("failed to find example: %w", context.DeadlineExceeded),
+			wantCode:   codes.DeadlineExceeded,
+			wantReason: apperrors.CodeTimeout,
+			wantRetry:  true,
+		},
+		{
+			name:       "unavailable",
+			id:         2,
+			err:        fmt.Errorf("failed to publish event: %w", domain.ErrEventPublishFailed),
+			wantCode:   codes.Unavailable,
+			wantReason: apperrors.CodeUnavailable,
+			wantRetry:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := grpcHandler.NewHandler(&failingExampleService{err: tt.err}, nil)
+			_, err := handler.GetExample(context.Background(), &proto.GetExampleRequest{Id: tt.id})
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("code = %v, want %v", st.Code(), tt.wantCode)
+			}
+
+			var reason, domainName, field string
+			var retry bool
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = d.GetReason()
+					domainName = d.GetDomain()
+				case *errdetails.BadRequest:
+					if len(d.GetFieldViolations()) > 0 {
+						field = d.GetFieldViolations()[0].GetField()
+					}
+				case *errdetails.RetryInfo:
+					retry = d.GetRetryDelay().AsDuration() > 0
+				}
+			}
+			if reason != string(tt.wantReason) {
+				t.Errorf("reason = %q, want %q", reason, tt.wantReason)
+			}
+			if domainName != grpcHandler.ErrorDomain {
+				t.Errorf("domain = %q, want %q", domainName, grpcHandler.ErrorDomain)
+			}
+			if field != tt.wantField {
+				t.Errorf("field = %q, want %q", field, tt.wantField)
+			}
+			if retry != tt.wantRetry {
+				t.Errorf("RetryInfo with a delay = %v, want %v", retry, tt.wantRetry)
+			}
+		})
+	}
+}
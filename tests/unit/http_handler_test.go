@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"encoding/json"
+	httpHandler "example-service/internal/adapters/inbound/http"
+	apperrors "example-service/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestHTTPHandler_Errors tests that service errors map to statuses and JSON bodies
+func TestHTTPHandler_Errors(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(httpHandler.RequestID)
+	httpHandler.NewHandler(&stubExampleService{}).RegisterRoutes(router)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantCode   apperrors.ErrorCode
+	}{
+		{
+			name:       "missing example returns 404",
+			method:     http.MethodGet,
+			path:       "/api/v1/examples/2",
+			wantStatus: http.StatusNotFound,
+			wantCode:   apperrors.CodeNotFound,
+		},
+		{
+			name:       "invalid id returns 400",
+			method:     http.MethodGet,
+			path:       "/api/v1/examples/abc",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   apperrors.CodeInvalidInput,
+		},
+		{
+			name:       "invalid body returns 400",
+			method:     http.MethodPost,
+			path:       "/api/v1/examples",
+			body:       "{",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   apperrors.CodeInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(httpHandler.RequestIDHeader, "req-123")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body apperrors.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON body: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %s, want %s", body.Code, tt.wantCode)
+			}
+			if body.RequestID != "req-123" {
+				t.Errorf("request_id = %q, want %q", body.RequestID, "req-123")
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package unit
+
+import (
+	"context"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/adapters/outbound/kafka"
+	"example-service/internal/config"
+	"example-service/internal/domain"
+	"example-service/pkg/cloudevents"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// newFakeKafka starts an in-process Kafka cluster with the given topics
+func newFakeKafka(t *testing.T, topics ...string) *kfake.Cluster {
+	t.Helper()
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(3, topics...))
+	if err != nil {
+		t.Fatalf("failed to start fake kafka: %v", err)
+	}
+	t.Cleanup(cluster.Close)
+	return cluster
+}
+
+func kafkaTestConfig(cluster *kfake.Cluster) config.KafkaConfig {
+	return config.KafkaConfig{
+		Brokers:     cluster.ListenAddrs(),
+		TopicPrefix: "example-service.",
+		Topics:      map[string]string{"ExampleDeleted": "example-tombstones"},
+		Acks:        "all",
+		Compression: "snappy",
+		Idempotent:  true,
+		ContentMode: cloudevents.ModeBinary,
+	}
+}
+
+func newTestEncoder(t *testing.T, format string) *envelope.Encoder {
+	t.Helper()
+	encoder, err := envelope.NewEncoder(envelope.Config{
+		Source:     "example-service",
+		SchemaBase: "urn:example-service:events",
+		Format:     format,
+	})
+	if err != nil {
+		t.Fatalf("NewEncoder() error = %v", err)
+	}
+	return encoder
+}
+
+// recordEvent decodes the CloudEvent carried by a record in either mode
+func recordEvent(t *testing.T, r *kgo.Record) *cloudevents.Event {
+	t.Helper()
+	attrs := make(map[string]string)
+	var contentType string
+	for _, h := range r.Headers {
+		if h.Key == "content-type" {
+			contentType = string(h.Value)
+		} else if name, ok := strings.CutPrefix(h.Key, "ce_"); ok {
+			attrs[name] = string(h.Value)
+		}
+	}
+
+	var (
+		ce  *cloudevents.Event
+		err error
+	)
+	if cloudevents.IsStructured(contentType) {
+		ce, err = cloudevents.UnmarshalStructured(r.Value)
+	} else {
+		ce, err = cloudevents.FromBinary(attrs, contentType, r.Value)
+	}
+	if err != nil {
+		t.Fatalf("failed to decode cloudevent: %v", err)
+	}
+	return ce
+}
+
+// TestKafkaEventPublisher_Publish tests topic routing, keying by example id
+// and the CloudEvents envelope in both content modes
+func TestKafkaEventPublisher_Publish(t *testing.T) {
+	for _, mode := range []string{cloudevents.ModeBinary, cloudevents.ModeStructured} {
+		t.Run(mode, func(t *testing.T) {
+			testKafkaPublish(t, mode)
+		})
+	}
+}
+
+func testKafkaPublish(t *testing.T, mode string) {
+	cluster := newFakeKafka(t, "example-service.example-created", "example-tombstones")
+	cfg := kafkaTestConfig(cluster)
+	cfg.ContentMode = mode
+	publisher, err := kafka.NewEventPublisher(cfg, newTestEncoder(t, envelope.FormatJSON))
+	if err != nil {
+		t.Fatalf("NewEventPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events := []*domain.Event{
+		domain.NewEvent(domain.EventTypeExampleCreated, 42, domain.ExampleCreatedEvent{ExampleID: 42, Name: "a"}, time.Now()),
+		domain.NewEvent(domain.EventTypeExampleDeleted, 42, domain.ExampleDeletedEvent{ExampleID: 42}, time.Now()),
+	}
+	for _, e := range events {
+		if err := publisher.Publish(ctx, e); err != nil {
+			t.Fatalf("Publish(%s) error = %v", e.Type, err)
+		}
+	}
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics("example-service.example-created", "example-tombstones"),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create consumer: %v", err)
+	}
+	defer consumer.Close()
+
+	got := make(map[string]*kgo.Record)
+	for len(got) < len(events) {
+		fetches := consumer.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("timed out waiting for records, got %d", len(got))
+		}
+		fetches.EachRecord(func(r *kgo.Record) {
+			got[r.Topic] = r
+		})
+	}
+
+	tests := []struct {
+		topic string
+		event *domain.Event
+	}{
+		{"example-service.example-created", events[0]},
+		{"example-tombstones", events[1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			r, ok := got[tt.topic]
+			if !ok {
+				t.Fatalf("no record on %s", tt.topic)
+			}
+			if string(r.Key) != "42" {
+				t.Errorf("key = %q, want %q", r.Key, "42")
+			}
+			ce := recordEvent(t, r)
+			if ce.ID != tt.event.ID || ce.Type != tt.event.Type || ce.Subject != "42" || ce.Source != "example-service" {
+				t.Errorf("envelope = %+v, want id %s, type %s, subject 42", ce, tt.event.ID, tt.event.Type)
+			}
+			if want := "urn:example-service:events:" + tt.event.Type + ":v1"; ce.DataSchema != want || ce.DataVersion != 1 {
+				t.Errorf("dataschema = %s (v%d), want %s (v1)", ce.DataSchema, ce.DataVersion, want)
+			}
+			if !strings.Contains(string(ce.Data), `"example_id":"42"`) {
+				t.Errorf("data = %s, want the event payload", ce.Data)
+			}
+		})
+	}
+}
+
+// TestKafkaEventPublisher_DeliveryError tests that undeliverable events are
+// reported to the caller
+func TestKafkaEventPublisher_DeliveryError(t *testing.T) {
+	cluster := newFakeKafka(t)
+	publisher, err := kafka.NewEventPublisher(kafkaTestConfig(cluster), newTestEncoder(t, envelope.FormatJSON))
+	if err != nil {
+		t.Fatalf("NewEventPublisher() error = %v", err)
+	}
+	defer publisher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	err = publisher.Publish(ctx, domain.NewEvent(domain.EventTypeExampleCreated, 1, nil, time.Now()))
+	if err == nil {
+		t.Fatal("Publish() to a missing topic succeeded, want error")
+	}
+}
+
+// TestKafkaEventPublisher_Config tests rejection of invalid producer settings
+func TestKafkaEventPublisher_Config(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.KafkaConfig
+	}{
+		{"no brokers", config.KafkaConfig{Acks: "all"}},
+		{"unknown acks", config.KafkaConfig{Brokers: []string{"localhost:9092"}, Acks: "some"}},
+		{"unknown compression", config.KafkaConfig{Brokers: []string{"localhost:9092"}, Acks: "all", Compression: "brotli"}},
+		{"idempotent without acks=all", config.KafkaConfig{Brokers: []string{"localhost:9092"}, Acks: "leader", Idempotent: true}},
+		{"unknown content mode", config.KafkaConfig{Brokers: []string{"localhost:9092"}, Acks: "all", ContentMode: "batched"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := kafka.NewEventPublisher(tt.cfg, newTestEncoder(t, envelope.FormatJSON)); err == nil {
+				t.Error("NewEventPublisher() error = nil, want error")
+			}
+		})
+	}
+}
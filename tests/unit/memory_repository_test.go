@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/adapters/outbound/memory"
+	"example-service/internal/domain"
+	"testing"
+)
+
+// TestMemoryExampleRepository_Names tests name uniqueness on create and
+// rename in both case sensitivity modes
+func TestMemoryExampleRepository_Names(t *testing.T) {
+	tests := []struct {
+		name            string
+		caseInsensitive bool
+		create          string
+		rename          string
+		wantCreate      error
+		wantRename      error
+	}{
+		{name: "distinct names", create: "b", rename: "c"},
+		{name: "duplicate name", create: "a", rename: "a", wantCreate: domain.ErrExampleAlreadyExists, wantRename: domain.ErrExampleAlreadyExists},
+		{name: "case sensitive", create: "A", rename: "A"},
+		{name: "case insensitive", caseInsensitive: true, create: "A", rename: "A", wantCreate: domain.ErrExampleAlreadyExists, wantRename: domain.ErrExampleAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			// Each store holds the examples "a" and "other"
+			newRepo := func() (*memory.ExampleRepository, *domain.Example) {
+				repo := memory.NewExampleRepository(memory.NewStore(memory.Options{CaseInsensitiveNames: tt.caseInsensitive}))
+				other := &domain.Example{Name: "other"}
+				for _, e := range []*domain.Example{{Name: "a"}, other} {
+					if err := repo.Create(ctx, e); err != nil {
+						t.Fatalf("Create() error = %v", err)
+					}
+				}
+				return repo, other
+			}
+
+			repo, _ := newRepo()
+			if err := repo.Create(ctx, &domain.Example{Name: tt.create}); !errors.Is(err, tt.wantCreate) {
+				t.Errorf("Create(%q) error = %v, want %v", tt.create, err, tt.wantCreate)
+			}
+			repo, other := newRepo()
+			other.Name = tt.rename
+			if err := repo.Update(ctx, other); !errors.Is(err, tt.wantRename) {
+				t.Errorf("Update(%q) error = %v, want %v", tt.rename, err, tt.wantRename)
+			}
+		})
+	}
+}
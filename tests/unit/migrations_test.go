@@ -0,0 +1,342 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"example-service/internal/database"
+	"example-service/internal/domain"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// TestParseMigrations tests loading and ordering migration files
+func TestParseMigrations(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"0010_add_column.up.sql":   file("ALTER TABLE a ADD b INT;"),
+				"0010_add_column.down.sql": file("ALTER TABLE a DROP b;"),
+				"0002_create.up.sql":       file("CREATE TABLE a ();"),
+				"0002_create.down.sql":     file("DROP TABLE a;"),
+			},
+			want: []string{"0002_create", "0010_add_column"},
+		},
+		{
+			name:    "missing down file",
+			files:   fstest.MapFS{"0001_create.up.sql": file("CREATE TABLE a ();")},
+			wantErr: "needs non-empty up and down files",
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"0001_create.up.sql":  file("CREATE TABLE a ();"),
+				"0001_other.down.sql": file("DROP TABLE a;"),
+			},
+			wantErr: "has two names",
+		},
+		{
+			name:    "invalid file name",
+			files:   fstest.MapFS{"create.sql": file("CREATE TABLE a ();")},
+			wantErr: "invalid migration file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := database.ParseMigrations(tt.files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseMigrations() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMigrations() error = %v", err)
+			}
+			var got []string
+			for _, m := range migrations {
+				got = append(got, m.String())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("migrations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMigrations_CoverModels tests that the embedded migrations of every
+// dialect create a column for every field of every GORM model, in the
+// table or by a later ALTER TABLE
+func TestMigrations_CoverModels(t *testing.T) {
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			migrations, err := database.Migrations(dialect)
+			if err != nil {
+				t.Fatalf("Migrations() error = %v", err)
+			}
+			var up strings.Builder
+			for _, m := range migrations {
+				up.WriteString(m.Up)
+			}
+			sql := up.String()
+
+			cache := &sync.Map{}
+			models := []interface{}{
+				&domain.Example{},
+				&domain.OutboxMessage{},
+				&domain.ProcessedEvent{},
+				&domain.WebhookSubscription{},
+				&domain.WebhookDelivery{},
+			}
+			for _, model := range models {
+				s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+				if err != nil {
+					t.Fatalf("failed to parse %T: %v", model, err)
+				}
+				table := regexp.MustCompile(`(?s)CREATE TABLE IF NOT EXISTS ` + s.Table + ` \((.*?)\n\);`).FindStringSubmatch(sql)
+				if table == nil {
+					t.Errorf("no migration creates table %s", s.Table)
+					continue
+				}
+				for _, field := range s.Fields {
+					if field.DBName == "" {
+						continue
+					}
+					created := regexp.MustCompile(`(?m)^\s+` + field.DBName + `\s`).MatchString(table[1])
+					added := regexp.MustCompile(`ALTER TABLE ` + s.Table + ` ADD COLUMN (IF NOT EXISTS )?` + field.DBName + `\s`).MatchString(sql)
+					if !created && !added {
+						t.Errorf("table %s has no column %s", s.Table, field.DBName)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestMigrator_SQLite tests applying, listing and rolling back the SQLite
+// migrations and the connection settings of SQLite databases
+func TestMigrator_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitGORM("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitGORM() error = %v", err)
+	}
+	db.Logger = logger.Discard
+	t.Cleanup(func() { database.Close(db) })
+
+	pragmas := map[string]string{"journal_mode": "wal", "busy_timeout": "5000", "foreign_keys": "1"}
+	for pragma, want := range pragmas {
+		var got string
+		if err := db.Raw("PRAGMA " + pragma).Scan(&got).Error; err != nil {
+			t.Fatalf("failed to read %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("%s = %q, want %q", pragma, got, want)
+		}
+	}
+
+	migrator, err := database.NewMigrator(db, database.MigrateOptions{})
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if err := database.CheckSchema(db, database.MigrateOptions{}); err == nil {
+		t.Error("CheckSchema() before migrating error = nil, want missing tables")
+	}
+	// Applying twice is a no-op
+	for i := 0; i < 2; i++ {
+		if err := migrator.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+	}
+	if err := database.CheckSchema(db, database.MigrateOptions{}); err != nil {
+		t.Errorf("CheckSchema() error = %v", err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			t.Errorf("migration %s is not applied", status.Migration)
+		}
+	}
+
+	for range statuses {
+		if err := migrator.Down(ctx); err != nil {
+			t.Fatalf("Down() error = %v", err)
+		}
+	}
+	if db.Migrator().HasTable("examples") {
+		t.Error("examples table exists after rolling back every migration")
+	}
+}
+
+// TestInitGORM_SQLiteOptions tests that options in a SQLite URL override the
+// defaults and that unsupported ones are refused
+func TestInitGORM_SQLiteOptions(t *testing.T) {
+	dir := t.TempDir()
+	db, err := database.InitGORM("sqlite://" + filepath.Join(dir, "test.db") + "?_pragma=busy_timeout(10000)&_txlock=deferred")
+	if err != nil {
+		t.Fatalf("InitGORM() error = %v", err)
+	}
+	db.Logger = logger.Discard
+	t.Cleanup(func() { database.Close(db) })
+	pragmas := map[string]string{"journal_mode": "wal", "busy_timeout": "10000", "foreign_keys": "1"}
+	for pragma, want := range pragmas {
+		var got string
+		if err := db.Raw("PRAGMA " + pragma).Scan(&got).Error; err != nil {
+			t.Fatalf("failed to read %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("%s = %q, want %q", pragma, got, want)
+		}
+	}
+
+	for _, query := range []string{"?_time_format=sqlite", "?_txlock=%zz"} {
+		if _, err := database.InitGORM("sqlite://" + filepath.Join(dir, "other.db") + query); err == nil {
+			t.Errorf("InitGORM() with %s error = nil, want error", query)
+		}
+	}
+}
+
+// TestMigrator_SQLiteVersions tests moving between versions, reading the status
+// without changing the database and migrations newer than this build
+func TestMigrator_SQLiteVersions(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitGORM("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitGORM() error = %v", err)
+	}
+	db.Logger = logger.Discard
+	t.Cleanup(func() { database.Close(db) })
+	migrator, err := database.NewMigrator(db, database.MigrateOptions{})
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	// applied returns the applied versions reported by Status
+	applied := func() []int64 {
+		t.Helper()
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		versions := []int64{}
+		for _, status := range statuses {
+			if status.AppliedAt != nil {
+				versions = append(versions, status.Version)
+			}
+		}
+		return versions
+	}
+
+	if got := applied(); len(got) != 0 {
+		t.Errorf("applied before migrating = %v, want none", got)
+	}
+	if db.Migrator().HasTable("schema_migrations") {
+		t.Error("Status() created the schema_migrations table")
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+		want []int64
+	}{
+		{name: "to 1", run: func() error { return migrator.To(ctx, 1) }, want: []int64{1}},
+		{name: "to 1 again", run: func() error { return migrator.To(ctx, 1) }, want: []int64{1}},
+		{name: "to 2", run: func() error { return migrator.To(ctx, 2) }, want: []int64{1, 2}},
+		{name: "down", run: func() error { return migrator.Down(ctx) }, want: []int64{1}},
+		{name: "down again", run: func() error { return migrator.Down(ctx) }, want: []int64{}},
+		{name: "down without migrations", run: func() error { return migrator.Down(ctx) }, want: []int64{}},
+		{name: "up", run: func() error { return migrator.Up(ctx) }, want: []int64{1, 2}},
+		{name: "to 0", run: func() error { return migrator.To(ctx, 0) }, want: []int64{}},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s: error = %v", step.name, err)
+		}
+		if got := applied(); !reflect.DeepEqual(got, step.want) {
+			t.Fatalf("%s: applied = %v, want %v", step.name, got, step.want)
+		}
+	}
+	if db.Migrator().HasTable("examples") {
+		t.Error("examples table exists after migrating to version 0")
+	}
+	if err := migrator.To(ctx, 9999); err == nil || !strings.Contains(err.Error(), "unknown to this build") {
+		t.Errorf("To(9999) error = %v, want an unknown migration", err)
+	}
+
+	// To creates the name index for the configured case sensitivity, which
+	// the schema check requires
+	if err := migrator.To(ctx, 2); err != nil {
+		t.Fatalf("To(2) error = %v", err)
+	}
+	if err := database.CheckSchema(db, database.MigrateOptions{}); err != nil {
+		t.Errorf("CheckSchema() after To(2) error = %v", err)
+	}
+	insensitive := database.MigrateOptions{CaseInsensitiveNames: true}
+	if err := database.CheckSchema(db, insensitive); err == nil || !strings.Contains(err.Error(), "idx_examples_name_lower") {
+		t.Errorf("CheckSchema() for case insensitive names error = %v, want the missing index", err)
+	}
+	insensitiveMigrator, err := database.NewMigrator(db, insensitive)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if err := insensitiveMigrator.To(ctx, 2); err != nil {
+		t.Fatalf("To(2) error = %v", err)
+	}
+	if err := database.CheckSchema(db, insensitive); err != nil {
+		t.Errorf("CheckSchema() for case insensitive names error = %v", err)
+	}
+	if err := db.Exec("INSERT INTO examples (name) VALUES ('a'), ('A')").Error; err == nil {
+		t.Error("inserting names differing in case error = nil, want a unique violation")
+	}
+	if err := migrator.To(ctx, 0); err != nil {
+		t.Fatalf("To(0) error = %v", err)
+	}
+
+	// A newer build applied migration 9999 to the same database
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if err := db.Exec("INSERT INTO schema_migrations (version, name) VALUES (9999, 'future')").Error; err != nil {
+		t.Fatalf("failed to record migration 9999: %v", err)
+	}
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	err = migrator.Up(ctx)
+	log.SetOutput(os.Stderr)
+	if err != nil {
+		t.Fatalf("Up() with a newer migration error = %v", err)
+	}
+	if !strings.Contains(logs.String(), "Database has migration 9999, which is newer than this build (2)") {
+		t.Errorf("Up() logged %q, want a warning about migration 9999", logs.String())
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if last := statuses[len(statuses)-1]; last.Version != 9999 || last.AppliedAt == nil || last.Up != "" {
+		t.Errorf("last status = %+v, want migration 9999 applied without SQL", last)
+	}
+	if err := migrator.Down(ctx); err == nil || !strings.Contains(err.Error(), "unknown to this build") {
+		t.Errorf("Down() error = %v, want migration 9999 unknown to this build", err)
+	}
+}
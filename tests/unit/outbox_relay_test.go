@@ -0,0 +1,433 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/adapters/outbound/memory"
+	"example-service/internal/adapters/outbound/postgres"
+	"example-service/internal/application"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/repositories"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOutboxRepository keeps outbox messages in memory
+type fakeOutboxRepository struct {
+	mu       sync.Mutex
+	messages []*domain.OutboxMessage
+}
+
+func (r *fakeOutboxRepository) Add(ctx context.Context, message *domain.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	message.ID = int64(len(r.messages) + 1)
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *fakeOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var pending []*domain.OutboxMessage
+	blocked := make(map[int64]bool)
+	for _, m := range r.messages {
+		if m.SentAt != nil {
+			continue
+		}
+		if !blocked[m.AggregateID] && m.DeadLetteredAt == nil {
+			pending = append(pending, m)
+		}
+		blocked[m.AggregateID] = true
+	}
+	return pending, nil
+}
+
+func (r *fakeOutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.messages[id-1].SentAt = &now
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkFailed(ctx context.Context, id int64, lastErr string, nextAttemptAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages[id-1].Attempts++
+	r.messages[id-1].LastError = lastErr
+	r.messages[id-1].NextAttemptAt = nextAttemptAt
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkDeadLettered(ctx context.Context, id int64, lastErr string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.messages[id-1].Attempts++
+	r.messages[id-1].LastError = lastErr
+	r.messages[id-1].DeadLetteredAt = &now
+	return nil
+}
+
+func (r *fakeOutboxRepository) ListDeadLettered(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var messages []*domain.OutboxMessage
+	for i := len(r.messages) - 1; i >= 0 && len(messages) < limit; i-- {
+		if r.messages[i].DeadLetteredAt != nil {
+			messages = append(messages, r.messages[i])
+		}
+	}
+	return messages, nil
+}
+
+func (r *fakeOutboxRepository) CountDeadLettered(ctx context.Context) (int64, error) {
+	messages, _ := r.ListDeadLettered(ctx, len(r.messages))
+	return int64(len(messages)), nil
+}
+
+func (r *fakeOutboxRepository) Requeue(ctx context.Context, id int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id < 1 || id > int64(len(r.messages)) || r.messages[id-1].DeadLetteredAt == nil {
+		return false, nil
+	}
+	r.messages[id-1].Attempts = 0
+	r.messages[id-1].DeadLetteredAt = nil
+	r.messages[id-1].NextAttemptAt = time.Now()
+	return true, nil
+}
+
+func (r *fakeOutboxRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]*domain.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var messages []*domain.OutboxMessage
+	for _, m := range r.messages {
+		if m.ID > afterID && len(messages) < limit {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+func (r *fakeOutboxRepository) ListByIDs(ctx context.Context, ids []int64) ([]*domain.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var messages []*domain.OutboxMessage
+	for _, m := range r.messages {
+		if slices.Contains(ids, m.ID) {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+func (r *fakeOutboxRepository) LastID(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.messages) == 0 {
+		return 0, nil
+	}
+	return r.messages[len(r.messages)-1].ID, nil
+}
+
+// recordingPublisher records published events and fails while failures > 0
+type recordingPublisher struct {
+	failures  int
+	published []*domain.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+// TestOutboxRelay_OrderingPerAggregate tests that a failed message holds back
+// later messages for its aggregate only
+func TestOutboxRelay_OrderingPerAggregate(t *testing.T) {
+	ctx := context.Background()
+	outbox := &fakeOutboxRepository{}
+	writer := application.NewOutboxPublisher(outbox)
+	for _, e := range []*domain.Event{
+		{Type: "ExampleCreated", AggregateID: 1},
+		{Type: "ExampleUpdated", AggregateID: 1},
+		{Type: "ExampleCreated", AggregateID: 2},
+	} {
+		if err := writer.Publish(ctx, e); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	publisher := &recordingPublisher{failures: 1}
+	relay := application.NewOutboxRelay(outbox, publisher, application.OutboxRelayConfig{})
+
+	if _, err := relay.RelayPending(ctx); err != nil {
+		t.Fatalf("RelayPending() error = %v", err)
+	}
+	if got := publishedAggregates(publisher); !reflect.DeepEqual(got, []int64{2}) {
+		t.Fatalf("after failure published %v, want [2]", got)
+	}
+	if outbox.messages[0].Attempts != 1 || outbox.messages[0].LastError == "" {
+		t.Errorf("failure not recorded: %+v", outbox.messages[0])
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := relay.RelayPending(ctx); err != nil {
+			t.Fatalf("RelayPending() error = %v", err)
+		}
+	}
+	var types []string
+	for _, e := range publisher.published[1:] {
+		types = append(types, e.Type)
+	}
+	if !reflect.DeepEqual(types, []string{"ExampleCreated", "ExampleUpdated"}) {
+		t.Errorf("aggregate 1 published %v, want created then updated", types)
+	}
+}
+
+func publishedAggregates(p *recordingPublisher) []int64 {
+	var ids []int64
+	for _, e := range p.published {
+		ids = append(ids, e.AggregateID)
+	}
+	return ids
+}
+
+// slowPublisher records published events from several goroutines, taking a
+// moment per event so that concurrent relays overlap
+type slowPublisher struct {
+	mu        sync.Mutex
+	published []*domain.Event
+}
+
+func (p *slowPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	time.Sleep(time.Millisecond)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, event)
+	return nil
+}
+
+func (p *slowPublisher) Close() error {
+	return nil
+}
+
+// TestOutboxRelay_SharedOutbox tests that two relays sharing an outbox
+// publish every message once and in order per aggregate
+func TestOutboxRelay_SharedOutbox(t *testing.T) {
+	tests := []struct {
+		name      string
+		newOutbox func(t *testing.T) repositories.OutboxRepository
+	}{
+		{
+			name: "memory",
+			newOutbox: func(t *testing.T) repositories.OutboxRepository {
+				return memory.NewOutboxRepository(memory.NewStore(memory.Options{}))
+			},
+		},
+		{
+			name: "sqlite",
+			newOutbox: func(t *testing.T) repositories.OutboxRepository {
+				return postgres.NewOutboxRepository(newSQLiteDB(t))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			outbox := tt.newOutbox(t)
+			writer := application.NewOutboxPublisher(outbox)
+			const aggregates, perAggregate = 3, 4
+			for i := 0; i < perAggregate; i++ {
+				for aggregate := int64(1); aggregate <= aggregates; aggregate++ {
+					event := &domain.Event{ID: fmt.Sprintf("%d-%d", aggregate, i), Type: "ExampleUpdated", AggregateID: aggregate}
+					if err := writer.Publish(ctx, event); err != nil {
+						t.Fatalf("Publish() error = %v", err)
+					}
+				}
+			}
+
+			publisher := &slowPublisher{}
+			published := func() int {
+				publisher.mu.Lock()
+				defer publisher.mu.Unlock()
+				return len(publisher.published)
+			}
+			var wg sync.WaitGroup
+			for r := 0; r < 2; r++ {
+				relay := application.NewOutboxRelay(outbox, publisher, application.OutboxRelayConfig{})
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					deadline := time.Now().Add(5 * time.Second)
+					for published() < aggregates*perAggregate && time.Now().Before(deadline) {
+						if n, err := relay.RelayPending(ctx); err != nil {
+							t.Errorf("RelayPending() error = %v", err)
+							return
+						} else if n == 0 {
+							time.Sleep(time.Millisecond)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			next := make(map[int64]int)
+			for _, e := range publisher.published {
+				if want := fmt.Sprintf("%d-%d", e.AggregateID, next[e.AggregateID]); e.ID != want {
+					t.Fatalf("published %s, want %s", e.ID, want)
+				}
+				next[e.AggregateID]++
+			}
+			if len(publisher.published) != aggregates*perAggregate {
+				t.Errorf("published %d events, want %d", len(publisher.published), aggregates*perAggregate)
+			}
+		})
+	}
+}
+
+// TestOutboxRelay_DeadLetter tests that a message failing too often is
+// parked, keeps holding back its aggregate and, once replayed, is published
+// before the messages that waited behind it
+func TestOutboxRelay_DeadLetter(t *testing.T) {
+	tests := []struct {
+		name      string
+		newOutbox func(t *testing.T) repositories.OutboxRepository
+	}{
+		{
+			name: "fake",
+			newOutbox: func(t *testing.T) repositories.OutboxRepository {
+				return &fakeOutboxRepository{}
+			},
+		},
+		{
+			name: "memory",
+			newOutbox: func(t *testing.T) repositories.OutboxRepository {
+				return memory.NewOutboxRepository(memory.NewStore(memory.Options{}))
+			},
+		},
+		{
+			name: "sqlite",
+			newOutbox: func(t *testing.T) repositories.OutboxRepository {
+				return postgres.NewOutboxRepository(newSQLiteDB(t))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			outbox := tt.newOutbox(t)
+			writer := application.NewOutboxPublisher(outbox)
+			for _, e := range []*domain.Event{
+				{ID: "evt-1", Type: "ExampleCreated", AggregateID: 1},
+				{ID: "evt-2", Type: "ExampleUpdated", AggregateID: 1},
+				{ID: "evt-3", Type: "ExampleDeleted", AggregateID: 1},
+			} {
+				if err := writer.Publish(ctx, e); err != nil {
+					t.Fatalf("Publish() error = %v", err)
+				}
+			}
+
+			metrics := application.NewPublishMetrics()
+			admin := application.NewEventAdminService(outbox, metrics, nil)
+			publisher := &recordingPublisher{failures: 2}
+			relay := application.NewOutboxRelay(outbox, publisher, application.OutboxRelayConfig{
+				MaxBackoff:      time.Nanosecond,
+				DeadLetterAfter: 2,
+				Metrics:         metrics,
+			})
+			relayAll := func() {
+				t.Helper()
+				for i := 0; i < 3; i++ {
+					if _, err := relay.RelayPending(ctx); err != nil {
+						t.Fatalf("RelayPending() error = %v", err)
+					}
+				}
+			}
+
+			relayAll()
+			if len(publisher.published) != 0 {
+				t.Fatalf("published %v, want nothing while evt-1 is parked", publisher.published)
+			}
+			list, err := admin.ListDeadLetters(ctx, 0)
+			if err != nil {
+				t.Fatalf("ListDeadLetters() error = %v", err)
+			}
+			if list.Total != 1 || list.DeadLetters[0].EventID != "evt-1" || list.DeadLetters[0].Attempts != 2 || list.DeadLetters[0].LastError == "" {
+				t.Fatalf("dead letters = %+v, want evt-1 after 2 attempts", list)
+			}
+
+			if err := admin.ReplayDeadLetter(ctx, list.DeadLetters[0].ID); err != nil {
+				t.Fatalf("ReplayDeadLetter() error = %v", err)
+			}
+			relayAll()
+			var ids []string
+			for _, e := range publisher.published {
+				ids = append(ids, e.ID)
+			}
+			if want := []string{"evt-1", "evt-2", "evt-3"}; !reflect.DeepEqual(ids, want) {
+				t.Errorf("published %v after the replay, want %v", ids, want)
+			}
+			if err := admin.ReplayDeadLetter(ctx, list.DeadLetters[0].ID); !errors.Is(err, domain.ErrDeadLetterNotFound) {
+				t.Errorf("second ReplayDeadLetter() error = %v, want %v", err, domain.ErrDeadLetterNotFound)
+			}
+
+			stats, err := admin.GetPublishStats(ctx)
+			if err != nil {
+				t.Fatalf("GetPublishStats() error = %v", err)
+			}
+			want := dto.PublishStatsResponse{Published: 3, Failed: 2, DeadLettered: 1, Replayed: 1, Subscribers: []*dto.SubscriberStatsResponse{}}
+			if !reflect.DeepEqual(*stats, want) {
+				t.Errorf("stats = %+v, want %+v", *stats, want)
+			}
+		})
+	}
+}
+
+// TestSyncOutboxPublisher tests that the fail policy publishes to the broker
+// before storing the event and reports broker failures
+func TestSyncOutboxPublisher(t *testing.T) {
+	ctx := context.Background()
+	outbox := &fakeOutboxRepository{}
+	broker := &recordingPublisher{failures: 1}
+	publisher := application.NewSyncOutboxPublisher(outbox, broker, nil)
+	event := &domain.Event{ID: "evt-1", Type: "ExampleCreated", AggregateID: 1}
+
+	err := publisher.Publish(ctx, event)
+	if !errors.Is(err, domain.ErrEventPublishFailed) {
+		t.Fatalf("Publish() error = %v, want %v", err, domain.ErrEventPublishFailed)
+	}
+	if appErr := application.MapError(err); appErr.Status != http.StatusServiceUnavailable {
+		t.Errorf("MapError() status = %d, want %d", appErr.Status, http.StatusServiceUnavailable)
+	}
+	if len(outbox.messages) != 0 {
+		t.Errorf("outbox has %d messages after a failed publish, want none", len(outbox.messages))
+	}
+
+	if err := publisher.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(broker.published) != 1 || len(outbox.messages) != 1 || outbox.messages[0].SentAt == nil {
+		t.Errorf("broker got %d events and outbox %+v, want one event stored as sent", len(broker.published), outbox.messages)
+	}
+	if pending, _ := outbox.ClaimPending(ctx, 10, time.Minute); len(pending) != 0 {
+		t.Errorf("ClaimPending() = %d messages, want none to relay", len(pending))
+	}
+}
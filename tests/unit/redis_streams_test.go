@@ -0,0 +1,151 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	inboundRedis "example-service/internal/adapters/inbound/redis"
+	redisAdapter "example-service/internal/adapters/outbound/redis"
+	"example-service/internal/application"
+	"example-service/internal/config"
+	"example-service/internal/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// newFakeRedis starts an in-process Redis server and a client for it
+func newFakeRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client, err := redisAdapter.NewClient(context.Background(), server.Addr(), "")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return server, client
+}
+
+// TestRedisEventPublisher_Publish tests that events are appended to a
+// bounded stream
+func TestRedisEventPublisher_Publish(t *testing.T) {
+	ctx := context.Background()
+	_, client := newFakeRedis(t)
+	publisher := redisAdapter.NewEventPublisher(client, config.RedisStreamConfig{
+		Stream: "example-service.events",
+		MaxLen: 2,
+	}, newTestEncoder(t, "json"))
+
+	var last *domain.Event
+	for i := int64(1); i <= 3; i++ {
+		last = domain.NewEvent(domain.EventTypeExampleCreated, i, domain.ExampleCreatedEvent{ExampleID: i, Name: "a"}, time.Now())
+		if err := publisher.Publish(ctx, last); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	entries, err := client.XRange(ctx, "example-service.events", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("XRange() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("stream length = %d, want 2", len(entries))
+	}
+	values := entries[len(entries)-1].Values
+	if values["ce_id"] != last.ID || values["ce_type"] != last.Type || values["ce_subject"] != "3" {
+		t.Errorf("entry = %v, want the last event's attributes", values)
+	}
+	if values["content-type"] != "application/json" {
+		t.Errorf("content-type = %v, want application/json", values["content-type"])
+	}
+}
+
+// TestRedisConsumer tests acknowledgement, reclaim of failed entries and
+// dead-lettering once attempts are used up
+func TestRedisConsumer(t *testing.T) {
+	_, client := newFakeRedis(t)
+	publisher := redisAdapter.NewEventPublisher(client, config.RedisStreamConfig{
+		Stream: "accounts",
+		MaxLen: 1000,
+	}, newTestEncoder(t, "json"))
+
+	var (
+		mu       sync.Mutex
+		handled  []string
+		attempts = make(map[string]int)
+	)
+	dispatcher := application.NewEventDispatcher("example-service", newFakeProcessedEvents(), nil)
+	application.Handle(dispatcher, domain.EventTypeExampleCreated, func(ctx context.Context, event *domain.InboundEvent, payload struct {
+		ExampleID string `json:"example_id"`
+	}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[payload.ExampleID]++
+		switch {
+		case payload.ExampleID == "2" && attempts["2"] == 1:
+			return errors.New("temporarily unavailable")
+		case payload.ExampleID == "13":
+			return errors.New("always fails")
+		}
+		handled = append(handled, payload.ExampleID)
+		return nil
+	})
+
+	ctx := context.Background()
+	for _, id := range []int64{1, 2, 13} {
+		e := domain.NewEvent(domain.EventTypeExampleCreated, id, domain.ExampleCreatedEvent{ExampleID: id}, time.Now())
+		if err := publisher.Publish(ctx, e); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	if err := client.XAdd(ctx, &redis.XAddArgs{Stream: "accounts", Values: map[string]interface{}{"data": "garbage"}}).Err(); err != nil {
+		t.Fatalf("XAdd() error = %v", err)
+	}
+
+	consumer, err := inboundRedis.NewConsumer(client, config.RedisStreamConfig{
+		ConsumerStreams:  []string{"accounts"},
+		ConsumerGroup:    "example-service",
+		ConsumerName:     "test",
+		DeadLetterStream: "example-service.dlt",
+		MaxAttempts:      3,
+		ClaimMinIdle:     time.Millisecond,
+	}, dispatcher)
+	if err != nil {
+		t.Fatalf("NewConsumer() error = %v", err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		consumer.Run(runCtx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		pending, _ := client.XPending(ctx, "accounts", "example-service").Result()
+		dlt, _ := client.XLen(ctx, "example-service.dlt").Result()
+		if pending != nil && pending.Count == 0 && dlt == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out: pending = %+v, dead letters = %d", pending, dlt)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handled) != 2 || handled[0] != "1" || handled[1] != "2" {
+		t.Errorf("handled = %v, want [1 2]", handled)
+	}
+	if attempts["2"] != 2 || attempts["13"] != 3 {
+		t.Errorf("attempts = %v, want 2 for the retried event and 3 for the failing one", attempts)
+	}
+}
@@ -0,0 +1,232 @@
+package unit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	httpHandler "example-service/internal/adapters/inbound/http"
+	"example-service/internal/application"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/services"
+	apperrors "example-service/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// watchFunc adapts a function to services.ExampleWatcher
+type watchFunc func(ctx context.Context, req *dto.WatchExamplesRequest, fn func(*dto.ExampleChange) error) error
+
+func (f watchFunc) WatchExamples(ctx context.Context, req *dto.WatchExamplesRequest, fn func(*dto.ExampleChange) error) error {
+	return f(ctx, req, fn)
+}
+
+func (f watchFunc) ValidateWatch(req *dto.WatchExamplesRequest) error {
+	return nil
+}
+
+// newStreamServer serves the change stream next to the example routes
+func newStreamServer(t *testing.T, watcher services.ExampleWatcher, heartbeat time.Duration) *httptest.Server {
+	t.Helper()
+	router := mux.NewRouter()
+	router.Use(httpHandler.RequestID)
+	httpHandler.NewStreamHandler(watcher, heartbeat).RegisterRoutes(router)
+	httpHandler.NewHandler(&stubExampleService{}).RegisterRoutes(router)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// openStream starts a stream request
+func openStream(t *testing.T, url, lastEventID string) *http.Response {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// openEvents starts a stream request and reads the retry line that opens it
+func openEvents(t *testing.T, url, lastEventID string) *bufio.Reader {
+	t.Helper()
+	body := bufio.NewReader(openStream(t, url, lastEventID).Body)
+	if lines := readSSE(t, body); len(lines) != 1 || lines[0] != "retry: 2000" {
+		t.Fatalf("first block = %q, want a retry line", lines)
+	}
+	return body
+}
+
+// readSSE reads the lines of the next event or comment block
+func readSSE(t *testing.T, r *bufio.Reader) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+// TestStreamHandler_Changes tests that changes are streamed as events that
+// can be resumed through Last-Event-ID
+func TestStreamHandler_Changes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outbox := &fakeOutboxRepository{}
+	publisher := application.NewOutboxPublisher(outbox)
+	feed := application.NewChangeFeed(outbox, application.ChangeFeedConfig{})
+	srv := newStreamServer(t, feed, time.Hour)
+
+	token := seedResumeToken(t, feed, publisher)
+	publishChange(t, publisher, domain.EventTypeExampleCreated, 1, "active")
+	publishChange(t, publisher, domain.EventTypeExampleCreated, 2, "active")
+	publishChange(t, publisher, domain.EventTypeExampleUpdated, 2, "inactive")
+	if _, err := feed.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	resp := openStream(t, srv.URL+"/api/v1/examples/stream?ids=2,3", token)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if lines := readSSE(t, body); len(lines) != 1 || lines[0] != "retry: 2000" {
+		t.Fatalf("first block = %q, want a retry line", lines)
+	}
+	var last string
+	for _, wantType := range []string{dto.ChangeCreated, dto.ChangeUpdated} {
+		lines := readSSE(t, body)
+		if len(lines) != 3 || !strings.HasPrefix(lines[0], "id: ") || lines[1] != "event: "+wantType {
+			t.Fatalf("event = %q, want an id and a %s event", lines, wantType)
+		}
+		var change dto.ExampleChange
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &change); err != nil {
+			t.Fatalf("failed to decode event data %q: %v", lines[2], err)
+		}
+		if change.ExampleID != 2 || change.ResumeToken != strings.TrimPrefix(lines[0], "id: ") {
+			t.Errorf("change = %+v, want example 2 with the event id as resume token", change)
+		}
+		last = change.ResumeToken
+	}
+	resp.Body.Close()
+
+	// Reconnecting with the last event id only sends newer changes
+	publishChange(t, publisher, domain.EventTypeExampleDeleted, 2, "inactive")
+	if _, err := feed.Poll(ctx); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	body = openEvents(t, srv.URL+"/api/v1/examples/stream?ids=2", last)
+	if lines := readSSE(t, body); len(lines) != 3 || lines[1] != "event: "+dto.ChangeDeleted {
+		t.Errorf("event after resuming = %q, want a deleted event", lines)
+	}
+}
+
+// TestStreamHandler_Open tests that the stream starts before the first change
+func TestStreamHandler_Open(t *testing.T) {
+	watcher := watchFunc(func(ctx context.Context, _ *dto.WatchExamplesRequest, _ func(*dto.ExampleChange) error) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	srv := newStreamServer(t, watcher, time.Hour)
+
+	resp := openStream(t, srv.URL+"/api/v1/examples/stream", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	if lines := readSSE(t, bufio.NewReader(resp.Body)); len(lines) != 1 || lines[0] != "retry: 2000" {
+		t.Errorf("first block = %q, want a retry line", lines)
+	}
+}
+
+// TestStreamHandler_Heartbeat tests that idle streams get heartbeat comments
+func TestStreamHandler_Heartbeat(t *testing.T) {
+	watcher := watchFunc(func(ctx context.Context, _ *dto.WatchExamplesRequest, _ func(*dto.ExampleChange) error) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	srv := newStreamServer(t, watcher, 10*time.Millisecond)
+
+	body := openEvents(t, srv.URL+"/api/v1/examples/stream", "")
+	for i := 0; i < 2; i++ {
+		if lines := readSSE(t, body); len(lines) != 1 || lines[0] != ": heartbeat" {
+			t.Fatalf("block = %q, want a heartbeat comment", lines)
+		}
+	}
+}
+
+// TestStreamHandler_Errors tests that invalid requests get plain error
+// responses and errors while streaming end the stream with an error event
+func TestStreamHandler_Errors(t *testing.T) {
+	feed := application.NewChangeFeed(&fakeOutboxRepository{}, application.ChangeFeedConfig{})
+	srv := newStreamServer(t, feed, time.Hour)
+
+	tests := []struct {
+		name        string
+		query       string
+		lastEventID string
+		wantField   string
+	}{
+		{name: "malformed ids", query: "?ids=1,abc", wantField: "ids"},
+		{name: "unknown status", query: "?status=archived", wantField: "status"},
+		{name: "invalid last event id", lastEventID: "bogus", wantField: "resume_token"},
+		{name: "invalid last event id parameter", query: "?last_event_id=bogus", wantField: "resume_token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := openStream(t, srv.URL+"/api/v1/examples/stream"+tt.query, tt.lastEventID)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+			var body apperrors.ErrorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode error body: %v", err)
+			}
+			if body.Code != apperrors.CodeInvalidInput || len(body.Fields) != 1 || body.Fields[0].Field != tt.wantField {
+				t.Errorf("error = %+v, want invalid %s", body, tt.wantField)
+			}
+		})
+	}
+
+	t.Run("feed stopped", func(t *testing.T) {
+		watcher := watchFunc(func(_ context.Context, _ *dto.WatchExamplesRequest, fn func(*dto.ExampleChange) error) error {
+			if err := fn(&dto.ExampleChange{Type: dto.ChangeCreated, ExampleID: 1, ResumeToken: "t1"}); err != nil {
+				return err
+			}
+			return domain.ErrChangeFeedStopped
+		})
+		srv := newStreamServer(t, watcher, time.Hour)
+		body := openEvents(t, srv.URL+"/api/v1/examples/stream", "")
+
+		readSSE(t, body)
+		lines := readSSE(t, body)
+		if len(lines) != 2 || lines[0] != "event: error" || !strings.Contains(lines[1], string(apperrors.CodeUnavailable)) {
+			t.Errorf("block = %q, want an unavailable error event", lines)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/adapters/outbound/postgres"
+	"example-service/internal/database"
+	"example-service/internal/domain"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSQLiteDB opens a throwaway SQLite database through database.InitGORM
+// and applies the SQLite migrations
+func newSQLiteDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.InitGORM("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitGORM() error = %v", err)
+	}
+	db.Logger = logger.Discard
+	t.Cleanup(func() { database.Close(db) })
+
+	migrator, err := database.NewMigrator(db, database.MigrateOptions{})
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	return db
+}
+
+// TestTransactor_WithinTransaction tests commit, rollback on error and
+// panic, and savepoints for nested calls
+func TestTransactor_WithinTransaction(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name      string
+		fn        func(ctx context.Context, tx *postgres.Transactor, create func(ctx context.Context, name string) error) error
+		wantErr   error
+		wantPanic bool
+		want      []string
+	}{
+		{
+			name: "commits on success",
+			fn: func(ctx context.Context, _ *postgres.Transactor, create func(context.Context, string) error) error {
+				if err := create(ctx, "a"); err != nil {
+					return err
+				}
+				return create(ctx, "b")
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "rolls back on error",
+			fn: func(ctx context.Context, _ *postgres.Transactor, create func(context.Context, string) error) error {
+				if err := create(ctx, "a"); err != nil {
+					return err
+				}
+				return errFailed
+			},
+			wantErr: errFailed,
+		},
+		{
+			name: "rolls back on panic",
+			fn: func(ctx context.Context, _ *postgres.Transactor, create func(context.Context, string) error) error {
+				if err := create(ctx, "a"); err != nil {
+					return err
+				}
+				panic("boom")
+			},
+			wantPanic: true,
+		},
+		{
+			name: "failed savepoint keeps outer writes",
+			fn: func(ctx context.Context, tx *postgres.Transactor, create func(context.Context, string) error) error {
+				if err := create(ctx, "a"); err != nil {
+					return err
+				}
+				err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+					if err := create(ctx, "b"); err != nil {
+						return err
+					}
+					return errFailed
+				})
+				if !errors.Is(err, errFailed) {
+					return err
+				}
+				return create(ctx, "c")
+			},
+			want: []string{"a", "c"},
+		},
+		{
+			name: "panicking savepoint rolls back everything",
+			fn: func(ctx context.Context, tx *postgres.Transactor, create func(context.Context, string) error) error {
+				if err := create(ctx, "a"); err != nil {
+					return err
+				}
+				return tx.WithinTransaction(ctx, func(ctx context.Context) error {
+					if err := create(ctx, "b"); err != nil {
+						return err
+					}
+					panic("boom")
+				})
+			},
+			wantPanic: true,
+		},
+		{
+			name: "failed outer call undoes committed savepoints",
+			fn: func(ctx context.Context, tx *postgres.Transactor, create func(context.Context, string) error) error {
+				err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+					return create(ctx, "a")
+				})
+				if err != nil {
+					return err
+				}
+				return errFailed
+			},
+			wantErr: errFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newSQLiteDB(t)
+			transactor := postgres.NewTransactor(db)
+			repo := postgres.NewExampleRepository(db)
+			create := func(ctx context.Context, name string) error {
+				return repo.Create(ctx, &domain.Example{Name: name, Status: "active"})
+			}
+
+			var err error
+			panicked := func() (panicked bool) {
+				defer func() { panicked = recover() != nil }()
+				err = transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+					return tt.fn(ctx, transactor, create)
+				})
+				return false
+			}()
+
+			if panicked != tt.wantPanic {
+				t.Errorf("panicked = %v, want %v", panicked, tt.wantPanic)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WithinTransaction() error = %v, want %v", err, tt.wantErr)
+			}
+			var got []string
+			if err := db.Model(&domain.Example{}).Pluck("name", &got).Error; err != nil {
+				t.Fatalf("failed to read examples: %v", err)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("stored examples = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"errors"
+	"example-service/internal/application/dto"
+	"example-service/pkg/validator"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestValidateStruct tests that validate tags report every failing field
+func TestValidateStruct(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        interface{}
+		wantFields []string
+	}{
+		{
+			name: "valid create request",
+			req:  &dto.CreateExampleRequest{Name: "example"},
+		},
+		{
+			name:       "empty name is required",
+			req:        &dto.CreateExampleRequest{Name: ""},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "blank name is required",
+			req:        &dto.CreateExampleRequest{Name: "   "},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "name longer than 255",
+			req:        &dto.CreateExampleRequest{Name: strings.Repeat("a", 256)},
+			wantFields: []string{"name"},
+		},
+		{
+			name: "empty update is valid",
+			req:  &dto.UpdateExampleRequest{},
+		},
+		{
+			name:       "every failing field is reported",
+			req:        &dto.UpdateExampleRequest{Name: strings.Repeat("a", 256), Status: "archived"},
+			wantFields: []string{"name", "status"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateStruct(tt.req)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var verrs validator.ValidationErrors
+			if !errors.As(err, &verrs) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+			var fields []string
+			for _, fe := range verrs {
+				fields = append(fields, fe.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
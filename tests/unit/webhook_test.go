@@ -0,0 +1,548 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"example-service/internal/adapters/outbound/envelope"
+	"example-service/internal/adapters/outbound/memory"
+	"example-service/internal/adapters/outbound/postgres"
+	"example-service/internal/adapters/outbound/webhook"
+	"example-service/internal/application"
+	"example-service/internal/application/dto"
+	"example-service/internal/domain"
+	"example-service/internal/ports/external"
+	"example-service/internal/ports/repositories"
+	"example-service/pkg/cloudevents"
+	signature "example-service/pkg/webhook"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeWebhookStore keeps subscriptions and deliveries in memory. It
+// implements both webhook repositories and hands out copies, like a database.
+type fakeWebhookStore struct {
+	mu            sync.Mutex
+	subscriptions []*domain.WebhookSubscription
+	deliveries    []*domain.WebhookDelivery
+}
+
+type fakeSubscriptions struct{ *fakeWebhookStore }
+
+type fakeDeliveries struct{ *fakeWebhookStore }
+
+func (s fakeSubscriptions) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub.ID = int64(len(s.subscriptions) + 1)
+	sub.CreatedAt = time.Now()
+	c := *sub
+	s.subscriptions = append(s.subscriptions, &c)
+	return nil
+}
+
+func (s fakeSubscriptions) FindByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subscriptions {
+		if sub.ID == id {
+			c := *sub
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s fakeSubscriptions) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return s.list(false), nil
+}
+
+func (s fakeSubscriptions) ListActive(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return s.list(true), nil
+}
+
+func (s fakeSubscriptions) list(activeOnly bool) []*domain.WebhookSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var subs []*domain.WebhookSubscription
+	for _, sub := range s.subscriptions {
+		if sub.Active || !activeOnly {
+			c := *sub
+			subs = append(subs, &c)
+		}
+	}
+	return subs
+}
+
+func (s fakeSubscriptions) RecordSuccess(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions[id-1].ConsecutiveFailures = 0
+	return nil
+}
+
+func (s fakeSubscriptions) RecordFailure(ctx context.Context, id int64, disableAfter int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub := s.subscriptions[id-1]
+	sub.ConsecutiveFailures++
+	if !sub.Active || sub.ConsecutiveFailures < disableAfter {
+		return false, nil
+	}
+	now := time.Now()
+	sub.Active = false
+	sub.DisabledAt = &now
+	return true, nil
+}
+
+func (s fakeSubscriptions) Delete(ctx context.Context, id int64) error {
+	return errors.New("not implemented")
+}
+
+func (s fakeDeliveries) Enqueue(ctx context.Context, d *domain.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d.ID = int64(len(s.deliveries) + 1)
+	c := *d
+	s.deliveries = append(s.deliveries, &c)
+	return nil
+}
+
+func (s fakeDeliveries) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []*domain.WebhookDelivery
+	seen := make(map[int64]bool)
+	for _, d := range s.deliveries {
+		if d.Status != domain.DeliveryPending || seen[d.SubscriptionID] {
+			continue
+		}
+		seen[d.SubscriptionID] = true
+		if s.subscriptions[d.SubscriptionID-1].Active && !d.NextAttemptAt.After(time.Now()) {
+			c := *d
+			due = append(due, &c)
+		}
+	}
+	return due, nil
+}
+
+func (s fakeDeliveries) MarkSucceeded(ctx context.Context, id int64, responseStatus int) error {
+	return s.record(id, responseStatus, "", func(d *domain.WebhookDelivery) {
+		now := time.Now()
+		d.Status = domain.DeliverySucceeded
+		d.DeliveredAt = &now
+	})
+}
+
+func (s fakeDeliveries) MarkRetrying(ctx context.Context, id int64, responseStatus int, lastErr string, nextAttemptAt time.Time) error {
+	return s.record(id, responseStatus, lastErr, func(d *domain.WebhookDelivery) {
+		d.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (s fakeDeliveries) MarkFailed(ctx context.Context, id int64, responseStatus int, lastErr string) error {
+	return s.record(id, responseStatus, lastErr, func(d *domain.WebhookDelivery) {
+		d.Status = domain.DeliveryFailed
+	})
+}
+
+func (s fakeDeliveries) record(id int64, responseStatus int, lastErr string, fn func(d *domain.WebhookDelivery)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := s.deliveries[id-1]
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = lastErr
+	fn(d)
+	return nil
+}
+
+func (s fakeDeliveries) ListBySubscription(ctx context.Context, subscriptionID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var list []*domain.WebhookDelivery
+	for i := len(s.deliveries) - 1; i >= 0 && len(list) < limit; i-- {
+		if s.deliveries[i].SubscriptionID == subscriptionID {
+			c := *s.deliveries[i]
+			list = append(list, &c)
+		}
+	}
+	return list, nil
+}
+
+// webhookReceiver is a local endpoint that verifies signatures and records
+// the events it accepts
+type webhookReceiver struct {
+	mu     sync.Mutex
+	secret string
+	status int
+	events []*cloudevents.Event
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := signature.Verify(rc.secret, r.Header.Get(signature.SignatureHeader), body, signature.DefaultTolerance, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if rc.status != http.StatusOK {
+		w.WriteHeader(rc.status)
+		return
+	}
+	ce, err := cloudevents.UnmarshalStructured(body)
+	if err != nil || r.Header.Get(signature.IDHeader) != ce.ID {
+		http.Error(w, "bad event", http.StatusBadRequest)
+		return
+	}
+	rc.mu.Lock()
+	rc.events = append(rc.events, ce)
+	rc.mu.Unlock()
+}
+
+// webhookFixture wires the webhook service, a publisher queueing
+// deliveries and a dispatcher posting them with the HTTP sender
+type webhookFixture struct {
+	store      *fakeWebhookStore
+	service    *application.WebhookService
+	publisher  external.EventPublisher
+	dispatcher *application.WebhookDispatcher
+}
+
+func newWebhookFixture(t *testing.T, cfg application.WebhookDispatcherConfig) *webhookFixture {
+	t.Helper()
+	store := &fakeWebhookStore{}
+	subs, deliveries := fakeSubscriptions{store}, fakeDeliveries{store}
+	sender := webhook.NewSender(newTestEncoder(t, envelope.FormatJSON), time.Second)
+	return &webhookFixture{
+		store:      store,
+		service:    application.NewWebhookService(subs, deliveries).(*application.WebhookService),
+		publisher:  application.NewWebhookPublisher(subs, deliveries),
+		dispatcher: application.NewWebhookDispatcher(subs, deliveries, sender, cfg),
+	}
+}
+
+// subscribe registers a receiver served by a local test server
+func (f *webhookFixture) subscribe(t *testing.T, rc *webhookReceiver, eventTypes ...string) int64 {
+	t.Helper()
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+	resp, err := f.service.CreateWebhook(context.Background(), &dto.CreateWebhookRequest{URL: server.URL, EventTypes: eventTypes})
+	if err != nil {
+		t.Fatalf("CreateWebhook() error = %v", err)
+	}
+	rc.secret = resp.Secret
+	return resp.ID
+}
+
+// TestWebhookSignature tests signing and verification of delivery bodies
+func TestWebhookSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"1"}`)
+	header := signature.Sign("secret", now, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{"valid", "secret", header, body, now, nil},
+		{"valid within tolerance", "secret", header, body, now.Add(4 * time.Minute), nil},
+		{"second signature matches", "secret", strings.Replace(header, ",", ",v1=00,", 1), body, now, nil},
+		{"wrong secret", "other", header, body, now, signature.ErrInvalidSignature},
+		{"tampered body", "secret", header, []byte(`{"id":"2"}`), now, signature.ErrInvalidSignature},
+		{"expired", "secret", header, body, now.Add(6 * time.Minute), signature.ErrExpiredSignature},
+		{"missing timestamp", "secret", header[strings.Index(header, ",")+1:], body, now, signature.ErrInvalidSignature},
+		{"empty", "secret", "", body, now, signature.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := signature.Verify(tt.secret, tt.header, tt.body, signature.DefaultTolerance, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestWebhookService_CreateWebhook tests subscription validation and that
+// the secret is only returned on creation
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.CreateWebhookRequest
+		wantErr bool
+	}{
+		{"all events", dto.CreateWebhookRequest{URL: "https://partner.example.com/hooks"}, false},
+		{"filtered", dto.CreateWebhookRequest{URL: "http://localhost:9000", EventTypes: []string{domain.EventTypeExampleDeleted}}, false},
+		{"own secret", dto.CreateWebhookRequest{URL: "https://partner.example.com", Secret: "0123456789abcdef"}, false},
+		{"missing url", dto.CreateWebhookRequest{}, true},
+		{"relative url", dto.CreateWebhookRequest{URL: "/hooks"}, true},
+		{"unsupported scheme", dto.CreateWebhookRequest{URL: "ftp://partner.example.com"}, true},
+		{"unknown event type", dto.CreateWebhookRequest{URL: "https://partner.example.com", EventTypes: []string{"ExampleArchived"}}, true},
+		{"short secret", dto.CreateWebhookRequest{URL: "https://partner.example.com", Secret: "short"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newWebhookFixture(t, application.WebhookDispatcherConfig{}).service
+			ctx := context.Background()
+
+			resp, err := service.CreateWebhook(ctx, &tt.req)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidInput) {
+					t.Errorf("CreateWebhook() error = %v, want invalid input", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateWebhook() error = %v", err)
+			}
+			if resp.Secret == "" || (tt.req.Secret != "" && resp.Secret != tt.req.Secret) {
+				t.Errorf("secret = %q, want generated or %q", resp.Secret, tt.req.Secret)
+			}
+
+			got, err := service.GetWebhook(ctx, resp.ID)
+			if err != nil {
+				t.Fatalf("GetWebhook() error = %v", err)
+			}
+			if got.Secret != "" || !got.Active {
+				t.Errorf("GetWebhook() = %+v, want active without secret", got)
+			}
+		})
+	}
+}
+
+// TestWebhookDispatcher_Deliver tests that events reach the subscriptions
+// accepting them as signed CloudEvents, and the delivery log
+func TestWebhookDispatcher_Deliver(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookFixture(t, application.WebhookDispatcherConfig{})
+	all := &webhookReceiver{status: http.StatusOK}
+	deletes := &webhookReceiver{status: http.StatusOK}
+	allID := f.subscribe(t, all)
+	f.subscribe(t, deletes, domain.EventTypeExampleDeleted)
+
+	events := []*domain.Event{
+		domain.NewEvent(domain.EventTypeExampleCreated, 7, domain.ExampleCreatedEvent{ExampleID: 7, Name: "a"}, time.Now()),
+		domain.NewEvent(domain.EventTypeExampleDeleted, 7, domain.ExampleDeletedEvent{ExampleID: 7}, time.Now()),
+	}
+	for _, e := range events {
+		if err := f.publisher.Publish(ctx, e); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := f.dispatcher.DeliverDue(ctx); err != nil {
+			t.Fatalf("DeliverDue() error = %v", err)
+		}
+	}
+
+	if len(all.events) != 2 || all.events[0].ID != events[0].ID || all.events[1].ID != events[1].ID {
+		t.Errorf("unfiltered subscription received %d events, want both in order", len(all.events))
+	}
+	if len(deletes.events) != 1 || deletes.events[0].Type != domain.EventTypeExampleDeleted {
+		t.Errorf("filtered subscription received %d events, want the delete only", len(deletes.events))
+	}
+	if ce := all.events[0]; ce.Subject != "7" || !strings.Contains(string(ce.Data), `"name":"a"`) {
+		t.Errorf("event = %+v, data %s, want subject 7 and the payload", ce, ce.Data)
+	}
+
+	log, err := f.service.ListDeliveries(ctx, allID, 0)
+	if err != nil {
+		t.Fatalf("ListDeliveries() error = %v", err)
+	}
+	if len(log.Deliveries) != 2 || log.Deliveries[0].EventID != events[1].ID {
+		t.Fatalf("delivery log = %+v, want two deliveries, newest first", log.Deliveries)
+	}
+	for _, d := range log.Deliveries {
+		if d.Status != domain.DeliverySucceeded || d.Attempts != 1 || d.ResponseStatus != http.StatusOK || d.DeliveredAt == "" {
+			t.Errorf("delivery = %+v, want succeeded on the first attempt", d)
+		}
+	}
+}
+
+// TestWebhookDispatcher_RetryAndDisable tests that a failing delivery is
+// retried with growing delays, holds back its queue without affecting other
+// subscriptions, and that the endpoint is disabled after repeated failures
+func TestWebhookDispatcher_RetryAndDisable(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookFixture(t, application.WebhookDispatcherConfig{
+		MaxAttempts:    3,
+		DisableAfter:   4,
+		InitialBackoff: 10 * time.Millisecond,
+	})
+	failing := &webhookReceiver{status: http.StatusServiceUnavailable}
+	healthy := &webhookReceiver{status: http.StatusOK}
+	failingID := f.subscribe(t, failing)
+	f.subscribe(t, healthy)
+
+	for _, id := range []int64{1, 2} {
+		e := domain.NewEvent(domain.EventTypeExampleCreated, id, domain.ExampleCreatedEvent{ExampleID: id}, time.Now())
+		if err := f.publisher.Publish(ctx, e); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	var delays []time.Duration
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		sub, _ := fakeSubscriptions{f.store}.FindByID(ctx, failingID)
+		if !sub.Active {
+			break
+		}
+		attempted := time.Now()
+		before := f.store.deliveries[0].Attempts
+		if _, err := f.dispatcher.DeliverDue(ctx); err != nil {
+			t.Fatalf("DeliverDue() error = %v", err)
+		}
+		if d := f.store.deliveries[0]; d.Attempts > before && d.Status == domain.DeliveryPending {
+			delays = append(delays, d.NextAttemptAt.Sub(attempted))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if len(delays) != 2 || delays[1] < delays[0]+5*time.Millisecond {
+		t.Errorf("retry delays = %v, want two growing delays", delays)
+	}
+
+	first, second := f.store.deliveries[0], f.store.deliveries[2]
+	if first.Status != domain.DeliveryFailed || first.Attempts != 3 || first.ResponseStatus != http.StatusServiceUnavailable || first.LastError == "" {
+		t.Errorf("first delivery = %+v, want failed after 3 attempts", first)
+	}
+	if second.Status != domain.DeliveryPending || second.Attempts != 1 {
+		t.Errorf("second delivery = %+v, want pending after 1 attempt", second)
+	}
+
+	got, err := f.service.GetWebhook(ctx, failingID)
+	if err != nil {
+		t.Fatalf("GetWebhook() error = %v", err)
+	}
+	if got.Active || got.DisabledAt == "" || got.ConsecutiveFailures != 4 {
+		t.Errorf("subscription = %+v, want disabled after 4 failures", got)
+	}
+	if len(healthy.events) != 2 {
+		t.Errorf("healthy subscription received %d events, want 2", len(healthy.events))
+	}
+}
+
+// TestWebhookDispatcher_SharedQueues tests that two dispatchers sharing the
+// queues send every delivery once, in order, and count failures exactly
+func TestWebhookDispatcher_SharedQueues(t *testing.T) {
+	tests := []struct {
+		name  string
+		repos func(t *testing.T) (repositories.WebhookSubscriptionRepository, repositories.WebhookDeliveryRepository)
+	}{
+		{
+			name: "memory",
+			repos: func(t *testing.T) (repositories.WebhookSubscriptionRepository, repositories.WebhookDeliveryRepository) {
+				store := memory.NewStore(memory.Options{})
+				return memory.NewWebhookSubscriptionRepository(store), memory.NewWebhookDeliveryRepository(store)
+			},
+		},
+		{
+			name: "sqlite",
+			repos: func(t *testing.T) (repositories.WebhookSubscriptionRepository, repositories.WebhookDeliveryRepository) {
+				db := newSQLiteDB(t)
+				return postgres.NewWebhookSubscriptionRepository(db), postgres.NewWebhookDeliveryRepository(db)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			subs, deliveries := tt.repos(t)
+			service := application.NewWebhookService(subs, deliveries)
+			subscribe := func(rc *webhookReceiver, requests *atomic.Int32) int64 {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests.Add(1)
+					rc.ServeHTTP(w, r)
+				}))
+				t.Cleanup(server.Close)
+				resp, err := service.CreateWebhook(ctx, &dto.CreateWebhookRequest{URL: server.URL})
+				if err != nil {
+					t.Fatalf("CreateWebhook() error = %v", err)
+				}
+				rc.secret = resp.Secret
+				return resp.ID
+			}
+			var healthyRequests, failingRequests atomic.Int32
+			healthy := &webhookReceiver{status: http.StatusOK}
+			failing := &webhookReceiver{status: http.StatusServiceUnavailable}
+			subscribe(healthy, &healthyRequests)
+			failingID := subscribe(failing, &failingRequests)
+
+			publisher := application.NewWebhookPublisher(subs, deliveries)
+			var events []string
+			for id := int64(1); id <= 4; id++ {
+				e := domain.NewEvent(domain.EventTypeExampleCreated, id, domain.ExampleCreatedEvent{ExampleID: id}, time.Now())
+				if err := publisher.Publish(ctx, e); err != nil {
+					t.Fatalf("Publish() error = %v", err)
+				}
+				events = append(events, e.ID)
+			}
+
+			sender := webhook.NewSender(newTestEncoder(t, envelope.FormatJSON), time.Second)
+			cfg := application.WebhookDispatcherConfig{
+				MaxAttempts:    100,
+				DisableAfter:   3,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+			}
+			done := func() bool {
+				sub, _ := subs.FindByID(ctx, failingID)
+				healthy.mu.Lock()
+				defer healthy.mu.Unlock()
+				return len(healthy.events) == len(events) && !sub.Active
+			}
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				dispatcher := application.NewWebhookDispatcher(subs, deliveries, sender, cfg)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					deadline := time.Now().Add(5 * time.Second)
+					for !done() && time.Now().Before(deadline) {
+						if _, err := dispatcher.DeliverDue(ctx); err != nil {
+							t.Errorf("DeliverDue() error = %v", err)
+							return
+						}
+						time.Sleep(time.Millisecond)
+					}
+				}()
+			}
+			wg.Wait()
+
+			var received []string
+			for _, ce := range healthy.events {
+				received = append(received, ce.ID)
+			}
+			if strings.Join(received, ",") != strings.Join(events, ",") || healthyRequests.Load() != int32(len(events)) {
+				t.Errorf("healthy subscription received %v in %d requests, want %v once each", received, healthyRequests.Load(), events)
+			}
+
+			sub, err := subs.FindByID(ctx, failingID)
+			if err != nil {
+				t.Fatalf("FindByID() error = %v", err)
+			}
+			if sub.Active || sub.ConsecutiveFailures != 3 || failingRequests.Load() != 3 {
+				t.Errorf("failing subscription = %+v after %d requests, want disabled after 3 counted failures", sub, failingRequests.Load())
+			}
+			log, err := deliveries.ListBySubscription(ctx, failingID, 10)
+			if err != nil {
+				t.Fatalf("ListBySubscription() error = %v", err)
+			}
+			if first := log[len(log)-1]; first.Attempts != 3 || first.Status != domain.DeliveryPending {
+				t.Errorf("first failing delivery = %+v, want pending after 3 attempts", first)
+			}
+		})
+	}
+}